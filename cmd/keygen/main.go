@@ -0,0 +1,74 @@
+// Command keygen prints fresh secrets for the web app as environment
+// variables.
+//
+// With -rotate it reads the keys currently set in the environment and moves
+// them to SESSION_PREVIOUS_KEYS and CSRF_PREVIOUS_KEYS, so cookies signed
+// with them keep working after the new keys are deployed.
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// keyLen is the size in bytes of every generated key.
+const keyLen = 32
+
+func main() {
+	rotate := flag.Bool("rotate", false, "keep the current keys from the environment as previous keys")
+	keep := flag.Int("keep", 1, "with -rotate, how many previous keys to keep")
+	flag.Parse()
+
+	if *keep < 1 {
+		fmt.Fprintln(os.Stderr, "keygen: -keep must be at least 1")
+		os.Exit(2)
+	}
+
+	auth, enc, csrf := newKey(), newKey(), newKey()
+	fmt.Printf("SESSION_AUTH_KEY=%s\n", auth)
+	fmt.Printf("SESSION_ENC_KEY=%s\n", enc)
+	fmt.Printf("CSRF_TOKEN_KEY=%s\n", csrf)
+
+	if !*rotate {
+		return
+	}
+
+	var sessionKeys, csrfKeys []string
+	if a, e := os.Getenv("SESSION_AUTH_KEY"), os.Getenv("SESSION_ENC_KEY"); a != "" && e != "" {
+		sessionKeys = append(sessionKeys, a+":"+e)
+	}
+	sessionKeys = append(sessionKeys, split(os.Getenv("SESSION_PREVIOUS_KEYS"))...)
+	if k := os.Getenv("CSRF_TOKEN_KEY"); k != "" {
+		csrfKeys = append(csrfKeys, k)
+	}
+	csrfKeys = append(csrfKeys, split(os.Getenv("CSRF_PREVIOUS_KEYS"))...)
+
+	fmt.Printf("SESSION_PREVIOUS_KEYS=%s\n", strings.Join(sessionKeys[:min(*keep, len(sessionKeys))], ","))
+	fmt.Printf("CSRF_PREVIOUS_KEYS=%s\n", strings.Join(csrfKeys[:min(*keep, len(csrfKeys))], ","))
+}
+
+// newKey returns keyLen random bytes in base64, like openssl rand -base64 32.
+func newKey() string {
+	b := make([]byte, keyLen)
+	if _, err := rand.Read(b); err != nil {
+		fmt.Fprintln(os.Stderr, "keygen:", err)
+		os.Exit(1)
+	}
+
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func split(list string) []string {
+	var items []string
+	for item := range strings.SplitSeq(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/api"
+	"github.com/tikimcrzx723/alejandrinasweb/views"
+)
+
+const ordersPerPage = 10
+
+// queryPage reads the page query parameter, defaulting to the first page.
+func queryPage(c echo.Context) int {
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page < 1 {
+		return 1
+	}
+
+	return page
+}
+
+func (ctrl Controller) AccountOrdersPage(c echo.Context) error {
+	orders, err := ctrl.client.ListOrders(c.Request().Context(), queryPage(c), ordersPerPage)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return views.AccountOrdersPage("Alejandrinas - Mis pedidos", orders.Orders, orders.Meta).
+		Render(ctrl.renderArgs(c))
+}
+
+func (ctrl Controller) AccountOrderPage(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	order, err := ctrl.client.GetOrder(c.Request().Context(), id)
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && (apiErr.IsNotFound() || apiErr.IsForbidden()) {
+			return echo.ErrNotFound
+		}
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return views.AccountOrderPage("Alejandrinas - Pedido", order.Order).
+		Render(ctrl.renderArgs(c))
+}
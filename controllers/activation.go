@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/api"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/mailer"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/validator"
+	"github.com/tikimcrzx723/alejandrinasweb/views"
+)
+
+// sendActivationEmail asks the backend for an activation token for email and
+// mails the link. Only backend errors are returned; a mail failure is logged
+// since the user can always ask for another link.
+func (ctrl Controller) sendActivationEmail(ctx context.Context, email string) error {
+	token, err := ctrl.client.CreateToken(ctx, dtos.CreateTokenRequest{
+		Email: email,
+		Scope: dtos.ScopeActivation,
+	})
+	if err != nil {
+		return err
+	}
+
+	msg := mailer.Message{
+		To:      email,
+		Subject: "Activa tu cuenta",
+		Text: fmt.Sprintf(
+			"Gracias por registrarte en Alejandrinas.\n\n"+
+				"Abre este enlace para confirmar tu correo y activar tu cuenta:\n%s\n\n"+
+				"El enlace se puede usar una sola vez y vence el %s.\n"+
+				"Si no creaste una cuenta, ignora este correo.\n",
+			ctrl.tokenURL("/activate", token.Token.Plaintext),
+			token.Token.ExpiresAt().Format("02/01/2006 15:04 MST"),
+		),
+	}
+	if err := ctrl.mailer.Send(ctx, msg); err != nil {
+		slog.ErrorContext(ctx, "could not send activation email", "err", err)
+	}
+
+	return nil
+}
+
+func (ctrl Controller) ActivateUser(c echo.Context) error {
+	token := c.QueryParam("token")
+	if token == "" {
+		return ctrl.renderInvalidActivationLink(c)
+	}
+
+	err := ctrl.client.ActivateUser(c.Request().Context(), dtos.ActivateUserRequest{Token: token})
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) &&
+			(apiErr.Code == api.CodeInvalidToken || apiErr.IsNotFound() || apiErr.IsUnauthorized() || apiErr.IsValidation()) {
+			return ctrl.renderInvalidActivationLink(c)
+		}
+		return err
+	}
+
+	flashSuccess(c, "Tu cuenta está activa. Ya puedes iniciar sesión.")
+
+	return c.Redirect(http.StatusSeeOther, "/login")
+}
+
+func (ctrl Controller) ResendActivationPage(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "no-store")
+	form := dtos.ResendActivationForm{Email: c.QueryParam("email")}
+	return views.ResendActivationPage("Alejandrinas - Activar cuenta", form, nil).
+		Render(ctrl.renderArgs(c))
+}
+
+// ResendActivation emails a new activation link. Like SendPasswordReset, it
+// answers the same way for unknown addresses.
+func (ctrl Controller) ResendActivation(c echo.Context) error {
+	var payload dtos.ResendActivationForm
+	if err := c.Bind(&payload); err != nil {
+		return err
+	}
+	payload.Email = strings.TrimSpace(payload.Email)
+
+	v := validator.New()
+	if payload.Validate(v); !v.Valid() {
+		c.Response().WriteHeader(http.StatusUnprocessableEntity)
+		return views.ResendActivationPage("Alejandrinas - Activar cuenta", payload, v.Errors).
+			Render(ctrl.renderArgs(c))
+	}
+
+	ctx := c.Request().Context()
+	if err := ctrl.sendActivationEmail(ctx, payload.Email); err != nil {
+		var apiErr *api.Error
+		if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+			return err
+		}
+	}
+
+	return views.ActivationSentPage("Alejandrinas - Revisa tu correo", payload.Email).
+		Render(ctrl.renderArgs(c))
+}
+
+// renderInactiveAccount stops the login of an unverified account and offers
+// to send a new activation link.
+func (ctrl Controller) renderInactiveAccount(c echo.Context, email string) error {
+	c.Response().WriteHeader(http.StatusForbidden)
+	return views.InactiveAccountPage("Alejandrinas - Activa tu cuenta", dtos.ResendActivationForm{Email: email}).
+		Render(ctrl.renderArgs(c))
+}
+
+func (ctrl Controller) renderInvalidActivationLink(c echo.Context) error {
+	c.Response().WriteHeader(http.StatusBadRequest)
+	return views.ErrorPage(
+		views.WithErrPageTitle("Enlace no válido"),
+		views.WithErrPageMsg("El enlace de activación venció o ya fue usado."),
+		views.WithErrPageLink("/activate/resend", "Solicitar otro enlace"),
+	).Render(ctrl.renderArgs(c))
+}
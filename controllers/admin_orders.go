@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/api"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/validator"
+	"github.com/tikimcrzx723/alejandrinasweb/routes/contexts"
+	"github.com/tikimcrzx723/alejandrinasweb/views"
+)
+
+const adminOrdersPerPage = 20
+
+func (ctrl Controller) AdminOrdersPage(c echo.Context) error {
+	var filter dtos.OrderFilter
+	if err := c.Bind(&filter); err != nil {
+		return err
+	}
+	filter.Page = queryPage(c)
+	filter.Limit = adminOrdersPerPage
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+
+	v := validator.New()
+	if filter.Validate(v); !v.Valid() {
+		c.Response().WriteHeader(http.StatusUnprocessableEntity)
+		return views.AdminOrdersPage("Alejandrinas - Órdenes", nil, dtos.Meta{}, filter, v.Errors).
+			Render(ctrl.renderArgs(c))
+	}
+
+	orders, err := ctrl.client.AdminListOrders(c.Request().Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return views.AdminOrdersPage("Alejandrinas - Órdenes", orders.Orders, orders.Meta, filter, nil).
+		Render(ctrl.renderArgs(c))
+}
+
+func (ctrl Controller) AdminOrderPage(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return ctrl.renderAdminOrder(c, id, dtos.UpdateOrderStatusForm{}, nil)
+}
+
+// renderAdminOrder renders the order detail page with its audit trail and the
+// status change form.
+func (ctrl Controller) renderAdminOrder(
+	c echo.Context,
+	id int,
+	form dtos.UpdateOrderStatusForm,
+	formErrors map[string]string,
+) error {
+	ctx := c.Request().Context()
+
+	order, err := ctrl.client.AdminGetOrder(ctx, id)
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+			return echo.ErrNotFound
+		}
+		return err
+	}
+
+	history, err := ctrl.client.GetOrderHistory(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return views.AdminOrderPage("Alejandrinas - Orden", order.Order, history.History, form, formErrors).
+		Render(ctrl.renderArgs(c))
+}
+
+func (ctrl Controller) UpdateOrderStatus(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	var payload dtos.UpdateOrderStatusForm
+	if err := c.Bind(&payload); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	order, err := ctrl.client.AdminGetOrder(ctx, id)
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+			return echo.ErrNotFound
+		}
+		return err
+	}
+
+	v := validator.New()
+	if payload.Validate(v, order.Order.Status); !v.Valid() {
+		c.Response().WriteHeader(http.StatusUnprocessableEntity)
+		return ctrl.renderAdminOrder(c, id, payload, v.Errors)
+	}
+
+	_, err = ctrl.client.UpdateOrderStatus(ctx, id, dtos.UpdateOrderStatusRequest{
+		Status:         payload.Status,
+		Reason:         strings.TrimSpace(payload.Reason),
+		Carrier:        strings.TrimSpace(payload.Carrier),
+		TrackingNumber: strings.TrimSpace(payload.TrackingNumber),
+	})
+	if err != nil {
+		if errors.Is(err, api.ErrSessionExpired) {
+			return err
+		}
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) {
+			link := fmt.Sprintf("/admin/dashboard/orders/%d", id)
+			switch {
+			case apiErr.IsUnauthorized(), apiErr.IsForbidden():
+				return ctrl.renderAPIError(c, apiErr, "No tienes autorización para modificar órdenes. Inicia sesión de nuevo.", "/login", "Iniciar sesión")
+			case apiErr.IsConflict(), apiErr.IsValidation():
+				return ctrl.renderAPIError(c, apiErr, apiErr.Message, link, "Volver a la orden")
+			}
+		}
+		return err
+	}
+
+	slog.InfoContext(ctx, "order status changed",
+		"order_id", id,
+		"from", order.Order.Status,
+		"to", payload.Status,
+		"reason", strings.TrimSpace(payload.Reason),
+		"admin_id", contexts.ExtractApp(ctx).UserID,
+	)
+	flashSuccess(c, fmt.Sprintf("Actualizamos el estado de la orden #%d.", id))
+
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/dashboard/orders/%d", id))
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gorilla/sessions"
+	"github.com/labstack/echo/v4"
+)
+
+// sessionRenewInterval is how often an active session records that it was
+// used, or half the idle timeout if that is shorter. Saving on every request
+// would rewrite the cookie each time.
+const sessionRenewInterval = time.Minute
+
+// saveAuthSession saves the auth session. A remembered login gets a cookie
+// that lasts until the login expires; any other login a cookie the browser
+// drops when it closes.
+func saveAuthSession(c echo.Context, s *sessions.Session) error {
+	s.Options.MaxAge = 0
+	if remember, _ := s.Values[authRememberKey].(bool); remember {
+		if expiresAt, ok := sessionTime(s, authExpiresAtKey); ok {
+			s.Options.MaxAge = max(1, int(time.Until(expiresAt).Seconds()))
+		}
+	}
+
+	return s.Save(c.Request(), c.Response())
+}
+
+// enforceSessionPolicy logs the user out once the login has outlived its
+// lifetime or, unless it is remembered, has been idle longer than the idle
+// timeout. An active session slides its idle deadline forward.
+func (ctrl Controller) enforceSessionPolicy(c echo.Context, s *sessions.Session) {
+	if isAuth, _ := s.Values[AuthUserAuthenticated].(bool); !isAuth {
+		return
+	}
+
+	now := time.Now()
+	remember, _ := s.Values[authRememberKey].(bool)
+
+	expiresAt, ok := sessionTime(s, authExpiresAtKey)
+	if !ok {
+		// Logged in before lifetimes were tracked.
+		expiresAt = now.Add(ctrl.cfg.Session.MaxLifetime)
+		s.Values[authExpiresAtKey] = expiresAt.Unix()
+	}
+	lastSeen, seen := sessionTime(s, authLastSeenKey)
+	if !seen {
+		lastSeen = now
+	}
+
+	var reason string
+	switch {
+	case !now.Before(expiresAt):
+		reason = "Tu sesión expiró. Inicia sesión de nuevo."
+	case !remember && now.Sub(lastSeen) > ctrl.cfg.Session.IdleTimeout:
+		reason = "Tu sesión se cerró por inactividad. Inicia sesión de nuevo."
+	}
+	if reason != "" {
+		if err := clearUserSession(c); err != nil {
+			slog.ErrorContext(c.Request().Context(), "could not clear expired session", "err", err)
+		}
+		flashInfo(c, reason)
+		return
+	}
+
+	if ok && seen && now.Sub(lastSeen) < min(sessionRenewInterval, ctrl.cfg.Session.IdleTimeout/2) {
+		return
+	}
+
+	s.Values[authLastSeenKey] = now.Unix()
+	if err := saveAuthSession(c, s); err != nil {
+		slog.ErrorContext(c.Request().Context(), "could not renew session", "err", err)
+	}
+}
+
+// sessionTime reads a time stored in a session as Unix seconds.
+func sessionTime(s *sessions.Session, key string) (time.Time, bool) {
+	sec, ok := s.Values[key].(int64)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return time.Unix(sec, 0), true
+}
@@ -0,0 +1,274 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/api"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+	"github.com/tikimcrzx723/alejandrinasweb/routes/contexts"
+	"github.com/tikimcrzx723/alejandrinasweb/views"
+)
+
+const (
+	cartSessionName = "cartSession"
+	cartItemsKey    = "CART_ITEMS"
+)
+
+// loadCart returns the cart stored in the session. Logged-in users keep a
+// copy of their backend cart there so the navbar count needs no API call.
+func loadCart(c echo.Context) dtos.Cart {
+	s, err := session.Get(cartSessionName, c)
+	if err != nil {
+		return dtos.Cart{}
+	}
+
+	items, _ := s.Values[cartItemsKey].([]dtos.CartItem)
+	return dtos.Cart{Items: items}
+}
+
+func saveCart(c echo.Context, cart dtos.Cart) error {
+	s, err := session.Get(cartSessionName, c)
+	if err != nil {
+		return err
+	}
+
+	s.Values[cartItemsKey] = cart.Items
+
+	return s.Save(c.Request(), c.Response())
+}
+
+// withToken returns ctx with token as the backend token, for calls made
+// before the session cookie carrying it reaches the browser.
+func withToken(ctx context.Context, token string) context.Context {
+	app := contexts.ExtractApp(ctx)
+	app.Token = token
+
+	return context.WithValue(ctx, contexts.AppKey{}, app)
+}
+
+// mergeGuestCart moves the items a guest collected into the backend cart of
+// the user that just logged in.
+func (ctrl Controller) mergeGuestCart(c echo.Context, token string) {
+	ctx := withToken(c.Request().Context(), token)
+
+	guest := loadCart(c)
+	resp, err := ctrl.client.MergeCart(ctx, guest.Items)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not merge guest cart", "err", err)
+		return
+	}
+
+	if err := saveCart(c, resp.Cart); err != nil {
+		slog.ErrorContext(ctx, "could not save cart session", "err", err)
+	}
+}
+
+func (ctrl Controller) CartPage(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	cart := loadCart(c)
+	if contexts.ExtractApp(ctx).IsAuthenticated {
+		resp, err := ctrl.client.GetCart(ctx)
+		if err != nil {
+			return err
+		}
+		cart = resp.Cart
+	}
+
+	lines := make([]dtos.CartLine, 0, len(cart.Items))
+	checked := dtos.Cart{}
+	for _, item := range cart.Items {
+		line := ctrl.checkCartItem(ctx, item)
+		lines = append(lines, line)
+		switch {
+		case line.Discontinued:
+			// Dropped: the product is gone for good.
+		case line.Available:
+			checked.Items = append(checked.Items, line.CartItem)
+		default:
+			// Out of stock or not verified: keep the item as it was so it
+			// comes back once the product can be bought again.
+			checked.Items = append(checked.Items, item)
+		}
+	}
+
+	if err := saveCart(c, checked); err != nil {
+		slog.ErrorContext(ctx, "could not save cart session", "err", err)
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return views.CartPage("Alejandrinas - Carrito", lines).
+		Render(ctrl.renderArgs(c))
+}
+
+// checkCartItem compares a cart item with the current product so the page
+// shows today's price and never more units than are in stock. Only a product
+// the backend reports as missing or inactive is marked Discontinued; any
+// other lookup failure leaves the line Unverified.
+func (ctrl Controller) checkCartItem(ctx context.Context, item dtos.CartItem) dtos.CartLine {
+	line := dtos.CartLine{CartItem: item, Name: item.SKU}
+
+	product, err := ctrl.client.GetProductBySKU(ctx, item.SKU)
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+			line.Discontinued = true
+			return line
+		}
+		slog.ErrorContext(ctx, "could not check cart item", "sku", item.SKU, "err", err)
+		line.Unverified = true
+		return line
+	}
+
+	p := product.Product
+	line.Name = p.Name
+	line.Stock = p.Stock
+	line.Discontinued = !p.IsActive
+	line.Available = p.IsActive && p.Stock > 0
+	if len(p.Images) > 0 {
+		line.ImageURL = p.Images[0].URL
+	}
+
+	if p.Price != item.UnitPrice {
+		line.PriceChanged = true
+		line.PreviousPrice = item.UnitPrice
+		line.UnitPrice = p.Price
+	}
+
+	if line.Quantity > p.Stock {
+		line.Quantity = p.Stock
+	}
+
+	return line
+}
+
+func (ctrl Controller) AddCartItem(c echo.Context) error {
+	var payload dtos.AddCartItemForm
+	if err := c.Bind(&payload); err != nil {
+		return err
+	}
+
+	if payload.Quantity < 1 {
+		payload.Quantity = 1
+	}
+
+	ctx := c.Request().Context()
+	product, err := ctrl.client.GetProductBySKU(ctx, payload.SKU)
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+			return ctrl.renderAPIError(c, apiErr, "El producto que buscas no fue encontrado.", "/", "Seguir comprando")
+		}
+		return err
+	}
+
+	p := product.Product
+	if !p.IsActive || p.Stock <= 0 {
+		c.Response().WriteHeader(http.StatusConflict)
+		return views.ErrorPage(
+			views.WithErrPageTitle("Producto agotado"),
+			views.WithErrPageMsg("Este producto no está disponible por ahora."),
+			views.WithErrPageLink("/", "Seguir comprando"),
+		).Render(ctrl.renderArgs(c))
+	}
+
+	cart := loadCart(c)
+	item := dtos.CartItem{
+		ProductID: p.ID,
+		SKU:       p.SKU,
+		Quantity:  payload.Quantity,
+		UnitPrice: p.Price,
+	}
+
+	requested := item.Quantity
+	if i := cart.Find(p.SKU); i >= 0 && cart.Items[i].Quantity+item.Quantity > p.Stock {
+		item.Quantity = p.Stock - cart.Items[i].Quantity
+	} else if item.Quantity > p.Stock {
+		item.Quantity = p.Stock
+	}
+
+	if item.Quantity <= 0 {
+		flashWarning(c, fmt.Sprintf("Ya tienes en el carrito todas las unidades disponibles de %s.", p.Name))
+		return c.Redirect(http.StatusSeeOther, "/cart")
+	}
+
+	if contexts.ExtractApp(ctx).IsAuthenticated {
+		resp, err := ctrl.client.AddCartItem(ctx, item)
+		if err != nil {
+			return err
+		}
+		cart = resp.Cart
+	} else {
+		cart.Add(item)
+	}
+
+	if err := saveCart(c, cart); err != nil {
+		return err
+	}
+
+	if item.Quantity < requested {
+		flashWarning(c, fmt.Sprintf("Solo agregamos %d de %s; no hay más unidades disponibles.", item.Quantity, p.Name))
+	} else {
+		flashSuccess(c, fmt.Sprintf("Agregamos %s a tu carrito.", p.Name))
+	}
+
+	return c.Redirect(http.StatusSeeOther, "/cart")
+}
+
+func (ctrl Controller) UpdateCartItem(c echo.Context) error {
+	var payload dtos.UpdateCartItemForm
+	if err := c.Bind(&payload); err != nil {
+		return err
+	}
+
+	return ctrl.setCartQuantity(c, c.Param("sku"), payload.Quantity)
+}
+
+func (ctrl Controller) RemoveCartItem(c echo.Context) error {
+	return ctrl.setCartQuantity(c, c.Param("sku"), 0)
+}
+
+func (ctrl Controller) setCartQuantity(c echo.Context, sku string, quantity int) error {
+	cart := loadCart(c)
+	i := cart.Find(sku)
+	if i < 0 {
+		return c.Redirect(http.StatusSeeOther, "/cart")
+	}
+
+	ctx := c.Request().Context()
+	if contexts.ExtractApp(ctx).IsAuthenticated {
+		var (
+			resp dtos.CartResponse
+			err  error
+		)
+		if quantity <= 0 {
+			resp, err = ctrl.client.RemoveCartItem(ctx, cart.Items[i].ProductID)
+		} else {
+			resp, err = ctrl.client.UpdateCartItem(ctx, cart.Items[i].ProductID, quantity)
+		}
+		if err != nil {
+			return err
+		}
+		cart = resp.Cart
+	} else {
+		cart.SetQuantity(sku, quantity)
+	}
+
+	if err := saveCart(c, cart); err != nil {
+		return err
+	}
+
+	if quantity <= 0 {
+		flashSuccess(c, "Quitamos el producto de tu carrito.")
+	} else {
+		flashSuccess(c, "Actualizamos tu carrito.")
+	}
+
+	return c.Redirect(http.StatusSeeOther, "/cart")
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+	"github.com/tikimcrzx723/alejandrinasweb/views"
+)
+
+const categoryProductsPerPage = 12
+
+// findActiveCategory returns the storefront category whose slug is slug.
+// Inactive categories are treated as missing.
+func (ctrl Controller) findActiveCategory(c echo.Context, slug string) (dtos.Category, error) {
+	categories, err := ctrl.client.GetAllCategories(c.Request().Context())
+	if err != nil {
+		return dtos.Category{}, err
+	}
+
+	for _, category := range categories.Categories {
+		if category.IsActive && category.Slug() == slug {
+			return category, nil
+		}
+	}
+
+	return dtos.Category{}, echo.ErrNotFound
+}
+
+func (ctrl Controller) CategoryProductsPage(c echo.Context) error {
+	category, err := ctrl.findActiveCategory(c, c.Param("slug"))
+	if err != nil {
+		return err
+	}
+
+	var form dtos.CategoryPageForm
+	if err := c.Bind(&form); err != nil {
+		return err
+	}
+	form.Page = queryPage(c)
+
+	active := true
+	products, err := ctrl.client.GetProducts(c.Request().Context(), dtos.ProductQuery{
+		Page:       form.Page,
+		Limit:      categoryProductsPerPage,
+		CategoryID: category.ID,
+		Active:     &active,
+		Sort:       form.Sort,
+		MinPrice:   form.MinPrice,
+		MaxPrice:   form.MaxPrice,
+	})
+	if err != nil {
+		return err
+	}
+
+	return views.CategoryPage("Alejandrinas - "+category.Name, category, products.Product, products.Meta, form).
+		Render(ctrl.renderArgs(c))
+}
@@ -0,0 +1,301 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/api"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/validator"
+	"github.com/tikimcrzx723/alejandrinasweb/views"
+)
+
+const (
+	checkoutSessionName = "checkoutSession"
+	checkoutStateKey    = "CHECKOUT_STATE"
+)
+
+var shippingMethods = []dtos.ShippingMethod{
+	{Code: "standard", Name: "Envío estándar", Days: "3 a 5 días hábiles", Price: 35},
+	{Code: "express", Name: "Envío express", Days: "1 a 2 días hábiles", Price: 60},
+	{Code: "pickup", Name: "Recoger en tienda", Days: "Disponible al día siguiente", Price: 0},
+}
+
+func findShippingMethod(code string) (dtos.ShippingMethod, bool) {
+	for _, method := range shippingMethods {
+		if method.Code == code {
+			return method, true
+		}
+	}
+
+	return dtos.ShippingMethod{}, false
+}
+
+func loadCheckout(c echo.Context) dtos.CheckoutState {
+	s, err := session.Get(checkoutSessionName, c)
+	if err != nil {
+		return dtos.CheckoutState{}
+	}
+
+	state, _ := s.Values[checkoutStateKey].(dtos.CheckoutState)
+	return state
+}
+
+func saveCheckout(c echo.Context, state dtos.CheckoutState) error {
+	s, err := session.Get(checkoutSessionName, c)
+	if err != nil {
+		return err
+	}
+
+	s.Values[checkoutStateKey] = state
+
+	return s.Save(c.Request(), c.Response())
+}
+
+func (ctrl Controller) Checkout(c echo.Context) error {
+	resp, err := ctrl.client.GetCart(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	if len(resp.Cart.Items) == 0 {
+		return c.Redirect(http.StatusSeeOther, "/cart")
+	}
+
+	return c.Redirect(http.StatusSeeOther, "/checkout/address")
+}
+
+func (ctrl Controller) CheckoutAddressPage(c echo.Context) error {
+	address := loadCheckout(c).Address
+	form := dtos.AddressForm{
+		FullName:   address.FullName,
+		Street:     address.Street,
+		City:       address.City,
+		State:      address.State,
+		PostalCode: address.PostalCode,
+		Phone:      address.Phone,
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return views.CheckoutAddressPage("Alejandrinas - Dirección de envío", form, nil).
+		Render(ctrl.renderArgs(c))
+}
+
+func (ctrl Controller) SaveCheckoutAddress(c echo.Context) error {
+	var payload dtos.AddressForm
+	if err := c.Bind(&payload); err != nil {
+		return err
+	}
+
+	v := validator.New()
+	if payload.Validate(v); !v.Valid() {
+		c.Response().WriteHeader(http.StatusUnprocessableEntity)
+		return views.CheckoutAddressPage("Alejandrinas - Dirección de envío", payload, v.Errors).
+			Render(ctrl.renderArgs(c))
+	}
+
+	state := loadCheckout(c)
+	state.Address = payload.Address()
+	if err := saveCheckout(c, state); err != nil {
+		return err
+	}
+
+	return c.Redirect(http.StatusSeeOther, "/checkout/shipping")
+}
+
+func (ctrl Controller) CheckoutShippingPage(c echo.Context) error {
+	state := loadCheckout(c)
+	if state.Address.Street == "" {
+		return c.Redirect(http.StatusSeeOther, "/checkout/address")
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return views.CheckoutShippingPage("Alejandrinas - Método de envío", shippingMethods, state.ShippingMethod, nil).
+		Render(ctrl.renderArgs(c))
+}
+
+func (ctrl Controller) SaveCheckoutShipping(c echo.Context) error {
+	var payload dtos.ShippingForm
+	if err := c.Bind(&payload); err != nil {
+		return err
+	}
+
+	v := validator.New()
+	_, ok := findShippingMethod(payload.Method)
+	v.Check(ok, "shipping_method", "Selecciona un método de envío")
+	if !v.Valid() {
+		c.Response().WriteHeader(http.StatusUnprocessableEntity)
+		return views.CheckoutShippingPage("Alejandrinas - Método de envío", shippingMethods, payload.Method, v.Errors).
+			Render(ctrl.renderArgs(c))
+	}
+
+	state := loadCheckout(c)
+	state.ShippingMethod = payload.Method
+	if err := saveCheckout(c, state); err != nil {
+		return err
+	}
+
+	return c.Redirect(http.StatusSeeOther, "/checkout/review")
+}
+
+// checkedCartLines re-checks the user's backend cart against the catalog and
+// keeps only the lines that can still be bought. The session copy of the
+// cart only feeds the navbar count and may be stale.
+func (ctrl Controller) checkedCartLines(c echo.Context) ([]dtos.CartLine, error) {
+	ctx := c.Request().Context()
+	resp, err := ctrl.client.GetCart(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]dtos.CartLine, 0, len(resp.Cart.Items))
+	for _, item := range resp.Cart.Items {
+		line := ctrl.checkCartItem(ctx, item)
+		if line.Available && line.Quantity > 0 {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, nil
+}
+
+// clearOrderedItems removes the ordered items from the backend cart, unless
+// the backend already did, and refreshes the session copy of the cart.
+func (ctrl Controller) clearOrderedItems(c echo.Context, items []dtos.CartItem) {
+	ctx := c.Request().Context()
+	for _, item := range items {
+		if _, err := ctrl.client.RemoveCartItem(ctx, item.ProductID); err != nil {
+			var apiErr *api.Error
+			if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+				slog.ErrorContext(ctx, "could not remove ordered cart item", "sku", item.SKU, "err", err)
+			}
+		}
+	}
+
+	cart := dtos.Cart{}
+	if resp, err := ctrl.client.GetCart(ctx); err != nil {
+		slog.ErrorContext(ctx, "could not reload cart", "err", err)
+	} else {
+		cart = resp.Cart
+	}
+
+	if err := saveCart(c, cart); err != nil {
+		slog.ErrorContext(ctx, "could not save cart session", "err", err)
+	}
+}
+
+func (ctrl Controller) CheckoutReviewPage(c echo.Context) error {
+	state := loadCheckout(c)
+	if state.Address.Street == "" {
+		return c.Redirect(http.StatusSeeOther, "/checkout/address")
+	}
+
+	method, ok := findShippingMethod(state.ShippingMethod)
+	if !ok {
+		return c.Redirect(http.StatusSeeOther, "/checkout/shipping")
+	}
+
+	lines, err := ctrl.checkedCartLines(c)
+	if err != nil {
+		return err
+	}
+	if len(lines) == 0 {
+		return c.Redirect(http.StatusSeeOther, "/cart")
+	}
+
+	if state.IdempotencyKey == "" {
+		state.IdempotencyKey = uuid.NewString()
+		if err := saveCheckout(c, state); err != nil {
+			return err
+		}
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return views.CheckoutReviewPage("Alejandrinas - Confirmar pedido", lines, state.Address, method, state.IdempotencyKey).
+		Render(ctrl.renderArgs(c))
+}
+
+func (ctrl Controller) ConfirmOrder(c echo.Context) error {
+	var payload dtos.ConfirmOrderForm
+	if err := c.Bind(&payload); err != nil {
+		return err
+	}
+
+	state := loadCheckout(c)
+
+	// A repeated submit of a form that already produced an order.
+	if payload.IdempotencyKey != "" && payload.IdempotencyKey == state.CompletedKey {
+		return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/checkout/confirmation/%d", state.CompletedOrderID))
+	}
+
+	if payload.IdempotencyKey == "" || payload.IdempotencyKey != state.IdempotencyKey {
+		return c.Redirect(http.StatusSeeOther, "/checkout/review")
+	}
+
+	lines, err := ctrl.checkedCartLines(c)
+	if err != nil {
+		return err
+	}
+	if len(lines) == 0 {
+		return c.Redirect(http.StatusSeeOther, "/cart")
+	}
+
+	items := make([]dtos.CartItem, 0, len(lines))
+	for _, line := range lines {
+		items = append(items, line.CartItem)
+	}
+
+	order, err := ctrl.client.CreateOrder(
+		c.Request().Context(),
+		dtos.CreateOrderRequest{
+			Items:           items,
+			ShippingAddress: state.Address,
+			ShippingMethod:  state.ShippingMethod,
+		},
+		state.IdempotencyKey,
+	)
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && (apiErr.IsConflict() || apiErr.IsValidation()) {
+			flashError(c, "No pudimos confirmar tu pedido: "+apiErr.Message)
+			return c.Redirect(http.StatusSeeOther, "/cart")
+		}
+		return err
+	}
+
+	if err := saveCheckout(c, dtos.CheckoutState{
+		Address:          state.Address,
+		CompletedKey:     state.IdempotencyKey,
+		CompletedOrderID: order.Order.ID,
+	}); err != nil {
+		return err
+	}
+
+	ctrl.clearOrderedItems(c, items)
+
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/checkout/confirmation/%d", order.Order.ID))
+}
+
+func (ctrl Controller) OrderConfirmationPage(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	order, err := ctrl.client.GetOrder(c.Request().Context(), id)
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && (apiErr.IsNotFound() || apiErr.IsForbidden()) {
+			return echo.ErrNotFound
+		}
+		return err
+	}
+
+	return views.OrderConfirmationPage("Alejandrinas - Pedido confirmado", order.Order).
+		Render(ctrl.renderArgs(c))
+}
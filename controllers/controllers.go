@@ -1,22 +1,27 @@
 package controllers
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"mime/multipart"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gorilla/csrf"
-	"github.com/gorilla/sessions"
 	"github.com/gosimple/slug"
 	"github.com/labstack/echo-contrib/session"
 	"github.com/labstack/echo/v4"
 	"github.com/tikimcrzx723/alejandrinasweb/internal/api"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/config"
 	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
-	"github.com/tikimcrzx723/alejandrinasweb/internal/env"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/mailer"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/search"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/validator"
 	"github.com/tikimcrzx723/alejandrinasweb/routes/contexts"
 	"github.com/tikimcrzx723/alejandrinasweb/views"
 )
@@ -25,45 +30,113 @@ const (
 	AuthSessionName       = "authSessionCookie"
 	authUserIDKey         = "USER_ID"
 	authUserEmailKey      = "USER_EMAIL"
+	authRefreshTokenKey   = "REFRESH_TOKEN"
 	AuthUserAuthenticated = "USER_AUTHENTICATED"
+	authRememberKey       = "REMEMBER"
+	authExpiresAtKey      = "EXPIRES_AT"
+	authLastSeenKey       = "LAST_SEEN_AT"
 	flashSessionName      = "flashSession"
 )
 
-func createAuthSession(
+// Backend field names mapped to the form inputs that show their errors.
+var (
+	registerFields = map[string]string{
+		"email":      "email",
+		"password":   "password",
+		"first_name": "first_name",
+		"last_name":  "last_name",
+		"phone":      "phone",
+	}
+	categoryFields = map[string]string{
+		"name":        "category_name",
+		"description": "category_description",
+	}
+	productFields = map[string]string{
+		"name":        "product_name",
+		"sku":         "product_name",
+		"category_id": "product_category",
+		"price":       "product_price",
+		"stock":       "product_stock",
+		"description": "product_description",
+	}
+)
+
+const (
+	homeProductsPerPage  = 12
+	adminProductsPerPage = 20
+)
+
+// Controller holds the dependencies shared by the HTTP handlers.
+type Controller struct {
+	client api.Service
+	mailer mailer.Mailer
+	// sessions is nil when sessions live in cookies.
+	sessions SessionManager
+	cfg      config.Config
+	catalog  *search.Catalog
+}
+
+func New(client api.Service, mail mailer.Mailer, sessions SessionManager, cfg config.Config) Controller {
+	ctrl := Controller{
+		client:   client,
+		mailer:   mail,
+		sessions: sessions,
+		cfg:      cfg,
+	}
+	ctrl.catalog = search.NewCatalog(ctrl.readCatalog, searchCatalogTTL)
+
+	return ctrl
+}
+
+// createAuthSession stores the logged-in user in the session and moves the
+// cart and wishlist the visitor built as a guest into their account. A
+// remembered login outlives the browser and is exempt from the idle timeout.
+func (ctrl Controller) createAuthSession(
 	ctx echo.Context,
 	user dtos.LoginResponse,
-	extendSession bool,
+	remember bool,
 ) error {
 	s, err := session.Get(AuthSessionName, ctx)
-	fmt.Println(s)
 	if err != nil {
 		return err
 	}
 
-	maxAge := 604800
-	if extendSession {
-		maxAge = maxAge * 2
+	lifetime := ctrl.cfg.Session.MaxLifetime
+	if remember {
+		lifetime = ctrl.cfg.Session.RememberLifetime
 	}
+	now := time.Now()
 
-	s.Options = &sessions.Options{
-		Path:     "/",
-		MaxAge:   maxAge,
-		HttpOnly: true,
-		Secure:   true,
+	if ctrl.sessions != nil {
+		if err := ctrl.sessions.Regenerate(ctx.Request().Context(), s); err != nil {
+			return err
+		}
 	}
 
 	s.Values[authUserIDKey] = user.Data.User.ID
 	s.Values[authUserEmailKey] = user.Data.User.Email
 	s.Values["TOKEN_KEY"] = user.Data.AccessToken
+	s.Values[authRefreshTokenKey] = user.Data.RefreshToken
 	s.Values[AuthUserAuthenticated] = true
 	s.Values["ROLE"] = user.Data.User.Role
+	s.Values[authRememberKey] = remember
+	s.Values[authExpiresAtKey] = now.Add(lifetime).Unix()
+	s.Values[authLastSeenKey] = now.Unix()
 
-	return s.Save(ctx.Request(), ctx.Response())
+	if err := saveAuthSession(ctx, s); err != nil {
+		return err
+	}
+
+	ctrl.mergeGuestCart(ctx, user.Data.AccessToken)
+	ctrl.mergeGuestWishlist(ctx, user.Data.AccessToken)
+
+	return nil
 }
 
 func setAppCtx(ctx echo.Context) context.Context {
 	appCtxkey := contexts.AppKey{}
-	appCtx := ctx.Get(appCtxkey.String())
+	appCtx, _ := ctx.Get(appCtxkey.String()).(contexts.App)
+	appCtx.CSRFToken = csrf.Token(ctx.Request())
 	withAppCtx := context.WithValue(
 		ctx.Request().Context(),
 		appCtxkey,
@@ -81,120 +154,92 @@ func setAppCtx(ctx echo.Context) context.Context {
 }
 
 func LogoutUser(ctx echo.Context) error {
+	if err := clearUserSession(ctx); err != nil {
+		return err
+	}
+	flashInfo(ctx, "Cerraste sesión.")
+
+	return ctx.Redirect(http.StatusSeeOther, "/")
+}
+
+// clearUserSession logs the user out and drops the cart and wishlist copies
+// kept for their account.
+func clearUserSession(ctx echo.Context) error {
 	s, err := session.Get(AuthSessionName, ctx)
 	if err != nil {
 		return err
 	}
 
 	s.Options.MaxAge = -1
+	// The session stays cached for the rest of the request.
+	clear(s.Values)
 
 	if err := s.Save(ctx.Request(), ctx.Response()); err != nil {
 		return err
 	}
 
-	return ctx.Redirect(http.StatusSeeOther, "/")
+	if err := saveCart(ctx, dtos.Cart{}); err != nil {
+		return err
+	}
+
+	return saveWishlist(ctx, dtos.Wishlist{})
+}
+
+func (ctrl Controller) RegisterProductPage(c echo.Context) error {
+	return ctrl.renderProductsPage(c, dtos.CreateProductForm{}, nil)
 }
 
-func RegisterProductPage(c echo.Context) error {
+// renderProductsPage renders the product table; a non-nil errs reopens the
+// product modal with productForm, the rejected submission.
+func (ctrl Controller) renderProductsPage(c echo.Context, productForm dtos.CreateProductForm, errs map[string]string) error {
 	token := csrf.Token(c.Request())
 
-	products, err := api.GetProducts(c.Request().Context(), env.GetString("API_URL", "http://localhost:8080/api/v1/"))
+	var form dtos.AdminProductsForm
+	if err := c.Bind(&form); err != nil {
+		return err
+	}
+	form.Page = queryPage(c)
+
+	query := dtos.ProductQuery{
+		Page:       form.Page,
+		Limit:      adminProductsPerPage,
+		Search:     strings.TrimSpace(form.Query),
+		CategoryID: form.Category,
+		Sort:       form.Sort,
+	}
+	switch form.Status {
+	case "active", "inactive":
+		active := form.Status == "active"
+		query.Active = &active
+	}
+
+	products, err := ctrl.client.GetProducts(c.Request().Context(), query)
 	if err != nil {
 		return err
 	}
 
 	c.Response().Header().Set("Cache-Control", "no-store")
-	return views.RegisterProduct("Alejandrinas - Registro de Producto", token, products).
-		Render(renderArgs(c))
+	if errs != nil {
+		c.Response().WriteHeader(http.StatusUnprocessableEntity)
+	}
+	return views.RegisterProduct("Alejandrinas - Registro de Producto", token, products, form, productForm, errs).
+		Render(ctrl.renderArgs(c))
 }
 
-// func addFlash(ctx echo.Context, flashType contexts.FlashType, msg string) error {
-// 	s, err := session.Get(flashSessionName, ctx)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	flash := contexts.FlashMessage{
-// 		ID:        uuid.New(),
-// 		Type:      flashType,
-// 		CreatedAt: time.Now(),
-// 		Message:   msg,
-// 	}
-
-// 	s.AddFlash(flash, flashSessionName)
-
-// 	if err := s.Save(ctx.Request(), ctx.Response()); err != nil {
-// 		return err
-// 	}
-
-// 	// También lo agregamos al contexto actual para que esté disponible en esta misma respuesta.
-// 	if existing, ok := ctx.Get(contexts.FlashKey{}.String()).([]contexts.FlashMessage); ok {
-// 		ctx.Set(contexts.FlashKey{}.String(), append(existing, flash))
-// 	} else {
-// 		ctx.Set(contexts.FlashKey{}.String(), []contexts.FlashMessage{flash})
-// 	}
-
-// 	return nil
-// }
-
-func RegisterFlashMessageContext(next echo.HandlerFunc) echo.HandlerFunc {
+func (ctrl Controller) RegisterAppContext(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		if strings.HasPrefix(c.Request().URL.Path, "/static") {
 			return next(c)
 		}
 
-		s, err := session.Get(flashSessionName, c)
-		if err != nil {
-			return next(c)
-		}
-
-		flashMessages := []contexts.FlashMessage{}
-		if flashes := s.Flashes(flashSessionName); len(flashes) > 0 {
-			for _, flash := range flashes {
-				if msg, ok := flash.(contexts.FlashMessage); ok {
-					flashMessages = append(flashMessages, contexts.FlashMessage{
-						ID:        msg.ID,
-						Type:      msg.Type,
-						CreatedAt: msg.CreatedAt,
-						Message:   msg.Message,
-					})
-				}
-			}
-		}
-
-		if err := s.Save(c.Request(), c.Response()); err != nil {
-			slog.ErrorContext(
-				c.Request().Context(),
-				"could not save flash session",
-				"err",
-				err,
-			)
-			return next(c)
-		}
-
-		c.Set(contexts.FlashKey{}.String(), flashMessages)
-
-		return next(c)
-	}
-}
-
-func RegisterAppContext(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
 		sess, err := session.Get(AuthSessionName, c)
 		if err != nil {
 			return err
 		}
+		ctrl.enforceSessionPolicy(c, sess)
 
 		isAuth, _ := sess.Values[AuthUserAuthenticated].(bool)
-		userID := 0
-		switch v := sess.Values[authUserIDKey].(type) {
-		case int:
-			userID = v
-		case int64:
-			userID = int(v)
-		case float64:
-			userID = int(v)
-		}
+		userID := SessionUserID(sess)
 		token := ""
 		switch v := sess.Values["TOKEN_KEY"].(type) {
 		case string:
@@ -217,6 +262,9 @@ func RegisterAppContext(next echo.HandlerFunc) echo.HandlerFunc {
 			IsAuthenticated: isAuth,
 			Token:           token,
 			Role:            role,
+			Permissions:     ctrl.cfg.Roles.Permissions(role),
+			CartCount:       loadCart(c).Count(),
+			Wishlist:        loadWishlist(c).SKUs(),
 		}
 
 		c.Set(contexts.AppKey{}.String(), appContext)
@@ -226,87 +274,153 @@ func RegisterAppContext(next echo.HandlerFunc) echo.HandlerFunc {
 	}
 }
 
-func SessionNew(ctx echo.Context) error {
+func (ctrl Controller) SessionNew(ctx echo.Context) error {
 	token := csrf.Token(ctx.Request())
 
 	ctx.Response().Header().Set("Cache-Control", "no-store")
 
-	return views.LoginPage("Login", token).Render(renderArgs(ctx))
+	return views.LoginPage("Login", token, dtos.LoginUserForm{}, nil).Render(ctrl.renderArgs(ctx))
 }
 
-func renderArgs(ctx echo.Context) (context.Context, io.Writer) {
-	return setAppCtx(ctx), ctx.Response().Writer
+func (ctrl Controller) renderArgs(ctx echo.Context) (context.Context, io.Writer) {
+	consumeFlashes(ctx)
+
+	categories, err := ctrl.client.GetAllCategories(ctx.Request().Context())
+	if err != nil {
+		slog.ErrorContext(
+			ctx.Request().Context(),
+			"could not load categories",
+			"err",
+			err,
+		)
+	}
+
+	withCategories := context.WithValue(
+		setAppCtx(ctx),
+		contexts.CategoriesKey{},
+		categories.Categories,
+	)
+	withUpload := context.WithValue(withCategories, contexts.UploadKey{}, ctrl.cfg.Upload)
+
+	return withUpload, ctx.Response().Writer
 }
 
-func Home(c echo.Context) error {
-	products, err := api.GetProducts(c.Request().Context(), env.GetString("API_URL", "http://localhost:8080/api/v1/"))
+func (ctrl Controller) Home(c echo.Context) error {
+	active := true
+	products, err := ctrl.client.GetProducts(c.Request().Context(), dtos.ProductQuery{
+		Page:   queryPage(c),
+		Limit:  homeProductsPerPage,
+		Active: &active,
+	})
 	if err != nil {
-		fmt.Println(err)
 		return err
 	}
-	return views.HomePage("Alejandrinas - Inicio", products.Product).
-		Render(renderArgs(c))
+	return views.HomePage("Alejandrinas - Inicio", products.Product, products.Meta).
+		Render(ctrl.renderArgs(c))
 }
 
-func Product(c echo.Context) error {
+func (ctrl Controller) Product(c echo.Context) error {
 	sku := c.Param("sku")
-	product, err := api.GetProductBySKU(c.Request().Context(), env.GetString("API_URL", "http://localhost:8080/api/v1/"), sku)
+	product, err := ctrl.client.GetProductBySKU(c.Request().Context(), sku)
 	if err != nil {
 		return views.ErrorPage(
 			views.WithErrPageTitle("El producto no existe o fue eliminado"),
 			views.WithErrPageMsg("El producto que buscas no fue encontrado"),
-		).Render(renderArgs(c))
+		).Render(ctrl.renderArgs(c))
 	}
 	return views.ProductPage("Alejandrinas - Detalle Producto", product.Product).
-		Render(renderArgs(c))
+		Render(ctrl.renderArgs(c))
 }
 
-func Register(c echo.Context) error {
+func (ctrl Controller) Register(c echo.Context) error {
 	token := csrf.Token(c.Request())
 
 	c.Response().Header().Set("Cache-Control", "no-store")
 
-	return views.RegisterPage("Alejandrinas - Registro", token).
-		Render(renderArgs(c))
+	return views.RegisterPage("Alejandrinas - Registro", token, dtos.RegisterUserForm{}, nil).
+		Render(ctrl.renderArgs(c))
+}
+
+func (ctrl Controller) renderRegisterErrors(c echo.Context, form dtos.RegisterUserForm, errs map[string]string) error {
+	form.Password = ""
+	c.Response().WriteHeader(http.StatusUnprocessableEntity)
+	return views.RegisterPage("Alejandrinas - Registro", csrf.Token(c.Request()), form, errs).
+		Render(ctrl.renderArgs(c))
 }
 
-func LoginPage(c echo.Context) error {
+func (ctrl Controller) LoginPage(c echo.Context) error {
 	token := csrf.Token(c.Request())
 
 	c.Response().Header().Set("Cache-Control", "no-store")
 
-	return views.LoginPage("Alejandrinas - Iniciar Sesión", token).
-		Render(renderArgs(c))
+	form := dtos.LoginUserForm{Next: localPath(c.QueryParam("next"), "")}
+	return views.LoginPage("Alejandrinas - Iniciar Sesión", token, form, nil).
+		Render(ctrl.renderArgs(c))
+}
+
+func (ctrl Controller) renderLoginErrors(c echo.Context, form dtos.LoginUserForm, errs map[string]string) error {
+	form.Password = ""
+	c.Response().WriteHeader(http.StatusUnprocessableEntity)
+	return views.LoginPage("Alejandrinas - Iniciar Sesión", csrf.Token(c.Request()), form, errs).
+		Render(ctrl.renderArgs(c))
 }
 
-func LoginUser(c echo.Context) error {
+func (ctrl Controller) LoginUser(c echo.Context) error {
 	var payload dtos.LoginUserForm
 	if err := c.Bind(&payload); err != nil {
 		return err
 	}
+	payload.Email = strings.TrimSpace(payload.Email)
+
+	v := validator.New()
+	if payload.Validate(v); !v.Valid() {
+		return ctrl.renderLoginErrors(c, payload, v.Errors)
+	}
 
-	user, err := api.Login(c.Request().Context(), env.GetString("API_URL", "http://localhost:8080/api/v1/"), dtos.LoginRequest{
+	user, err := ctrl.client.Login(c.Request().Context(), dtos.LoginRequest{
 		Email:    payload.Email,
 		Password: payload.Password,
 	})
 	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) {
+			switch {
+			case apiErr.IsUnauthorized(), apiErr.IsNotFound():
+				return ctrl.renderLoginErrors(c, payload, map[string]string{"password": "Correo o contraseña incorrectos."})
+			case apiErr.IsForbidden():
+				return ctrl.renderInactiveAccount(c, payload.Email)
+			}
+		}
 		return err
 	}
 
-	if err := createAuthSession(c, user, false); err != nil {
+	// Unverified accounts get no session until they follow the activation link.
+	if !user.Data.User.IsActive {
+		return ctrl.renderInactiveAccount(c, payload.Email)
+	}
+
+	if err := ctrl.createAuthSession(c, user, payload.Remember); err != nil {
 		return err
 	}
+	flashSuccess(c, fmt.Sprintf("Hola de nuevo, %s.", cmp.Or(user.Data.User.FirstName, user.Data.User.Email)))
 
-	return c.Redirect(http.StatusSeeOther, "/")
+	return c.Redirect(http.StatusSeeOther, LoginRedirect(payload.Next))
 }
 
-func CreateUser(c echo.Context) error {
+func (ctrl Controller) CreateUser(c echo.Context) error {
 	var payload dtos.RegisterUserForm
 	if err := c.Bind(&payload); err != nil {
 		return err
 	}
+	payload.Email = strings.TrimSpace(payload.Email)
+	payload.Phone = strings.TrimSpace(payload.Phone)
+
+	v := validator.New()
+	if payload.Validate(v); !v.Valid() {
+		return ctrl.renderRegisterErrors(c, payload, v.Errors)
+	}
 
-	_, err := api.Register(c.Request().Context(), env.GetString("API_URL", "http://localhost:8080/api/v1/"), dtos.RegisterRequest{
+	_, err := ctrl.client.Register(c.Request().Context(), dtos.RegisterRequest{
 		Email:     payload.Email,
 		Password:  payload.Password,
 		FirstName: payload.FirstName,
@@ -314,41 +428,81 @@ func CreateUser(c echo.Context) error {
 		Phone:     payload.Phone,
 	})
 	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) {
+			switch {
+			case apiErr.IsConflict():
+				return ctrl.renderRegisterErrors(c, payload, map[string]string{"email": "Ese correo electrónico ya está registrado."})
+			case apiErr.IsValidation():
+				return ctrl.renderRegisterErrors(c, payload, formErrors(apiErr, registerFields, "email"))
+			}
+		}
 		return err
 	}
-	return c.Redirect(http.StatusSeeOther, "/")
+
+	// The account exists at this point; if the link cannot be created the
+	// user can still ask for another one from the sent page.
+	if err := ctrl.sendActivationEmail(c.Request().Context(), payload.Email); err != nil {
+		slog.ErrorContext(c.Request().Context(), "could not create activation token", "err", err)
+	}
+
+	return views.ActivationSentPage("Alejandrinas - Revisa tu correo", payload.Email).
+		Render(ctrl.renderArgs(c))
 }
 
-func CreateCategory(c echo.Context) error {
+func (ctrl Controller) CreateCategory(c echo.Context) error {
 	var payload dtos.CreateCategoryForm
 	if err := c.Bind(&payload); err != nil {
 		return err
 	}
 
-	token := contexts.ExtractToken(c.Request().Context())
+	v := validator.New()
+	if payload.Validate(v); !v.Valid() {
+		return ctrl.renderCategoryErrors(c, payload, v.Errors)
+	}
 
-	_, err := api.CreateCategory(
+	_, err := ctrl.client.CreateCategory(
 		c.Request().Context(),
-		env.GetString("API_URL", "http://localhost:8080/api/v1/"),
 		dtos.CreateCategoryRequest{
 			Name:        payload.Name,
 			Description: payload.Description},
-		token,
 	)
 
 	if err != nil {
+		if errors.Is(err, api.ErrSessionExpired) {
+			return err
+		}
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) {
+			switch {
+			case apiErr.IsUnauthorized(), apiErr.IsForbidden():
+				return ctrl.renderAPIError(c, apiErr, "No tienes autorización para crear categorías. Inicia sesión de nuevo.", "/login", "Iniciar sesión")
+			case apiErr.IsConflict():
+				return ctrl.renderCategoryErrors(c, payload, map[string]string{"category_name": "Ya existe una categoría con ese nombre."})
+			case apiErr.IsValidation():
+				return ctrl.renderCategoryErrors(c, payload, formErrors(apiErr, categoryFields, "category_name"))
+			}
+		}
 		return err
 	}
 
+	flashSuccess(c, fmt.Sprintf("Creamos la categoría %s.", strings.TrimSpace(payload.Name)))
 	return c.Redirect(http.StatusSeeOther, "/admin/dashboard/product/register")
 }
 
-func CreateProduct(c echo.Context) error {
+func (ctrl Controller) CreateProduct(c echo.Context) error {
 	var payload dtos.CreateProductForm
 	if err := c.Bind(&payload); err != nil {
 		return err
 	}
 
+	if errs, err := ctrl.validateProduct(c, payload); err != nil || errs != nil {
+		if err != nil {
+			return err
+		}
+		return ctrl.renderProductsPage(c, payload, errs)
+	}
+
 	form, err := c.MultipartForm()
 	if err != nil {
 		return err
@@ -358,12 +512,14 @@ func CreateProduct(c echo.Context) error {
 	if form != nil && len(form.File["images"]) > 0 {
 		images = append(images, form.File["images"]...)
 	}
+	if len(images) > ctrl.cfg.Upload.MaxImages {
+		return ctrl.renderProductsPage(c, payload, map[string]string{
+			"images": fmt.Sprintf("Puedes subir hasta %d imágenes a la vez.", ctrl.cfg.Upload.MaxImages),
+		})
+	}
 
-	token := contexts.ExtractToken(c.Request().Context())
-
-	product, err := api.CreateProduct(
+	product, err := ctrl.client.CreateProduct(
 		c.Request().Context(),
-		env.GetString("API_URL", "http://localhost:8080/api/v1/"),
 		dtos.CreateProductRequest{
 			Name:        payload.Name,
 			Description: payload.Description,
@@ -372,37 +528,61 @@ func CreateProduct(c echo.Context) error {
 			Stock:       payload.Stock,
 			SKU:         slug.Make(payload.Name),
 		},
-		token,
 	)
 
 	if err != nil {
+		if errors.Is(err, api.ErrSessionExpired) {
+			return err
+		}
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) {
+			switch {
+			case apiErr.IsUnauthorized(), apiErr.IsForbidden():
+				return ctrl.renderAPIError(c, apiErr, "No tienes autorización para crear productos. Inicia sesión de nuevo.", "/login", "Iniciar sesión")
+			case apiErr.IsConflict():
+				return ctrl.renderProductsPage(c, payload, map[string]string{"product_name": "Ya existe un producto con ese nombre."})
+			case apiErr.IsValidation():
+				return ctrl.renderProductsPage(c, payload, formErrors(apiErr, productFields, "product_name"))
+			}
+		}
 		return err
 	}
+	ctrl.catalog.Invalidate()
 
 	if len(images) > 0 {
-		_, err := api.AddProductImages(
+		_, err := ctrl.client.AddProductImages(
 			c.Request().Context(),
-			env.GetString("API_URL", "http://localhost:8080/api/v1/"),
 			product.Product.ID,
 			images,
-			token,
 		)
 		if err != nil {
-			fmt.Println(err)
-			return err
+			slog.ErrorContext(c.Request().Context(), "could not upload product images", "product_id", product.Product.ID, "err", err)
+			flashWarning(c, fmt.Sprintf("Creamos %s, pero algunas imágenes no se pudieron subir.", product.Product.Name))
+			return c.Redirect(http.StatusSeeOther, "/admin/dashboard/product/register")
 		}
 	}
 
+	flashSuccess(c, fmt.Sprintf("Creamos el producto %s.", product.Product.Name))
 	return c.Redirect(http.StatusSeeOther, "/admin/dashboard/product/register")
 }
 
-func UpdateProduct(c echo.Context) error {
+func (ctrl Controller) UpdateProduct(c echo.Context) error {
 	var payload dtos.CreateProductForm
 	if err := c.Bind(&payload); err != nil {
 		return err
 	}
-	token := contexts.ExtractToken(c.Request().Context())
-	_, err := api.UpdateProduct(c.Request().Context(), env.GetString("API_URL", "http://localhost:8080/api/v1/"), token, payload.ID, dtos.UpdateProductRequest{
+	if payload.ID <= 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "missing product id")
+	}
+
+	if errs, err := ctrl.validateProduct(c, payload); err != nil || errs != nil {
+		if err != nil {
+			return err
+		}
+		return ctrl.renderProductsPage(c, payload, errs)
+	}
+
+	product, err := ctrl.client.UpdateProduct(c.Request().Context(), payload.ID, dtos.UpdateProductRequest{
 		Name:        payload.Name,
 		Description: payload.Description,
 		Price:       payload.Price,
@@ -410,17 +590,56 @@ func UpdateProduct(c echo.Context) error {
 		Stock:       payload.Stock,
 	})
 	if err != nil {
-		fmt.Print(err)
-		return c.Redirect(http.StatusSeeOther, "/admin/dashboard/product/register")
+		if errors.Is(err, api.ErrSessionExpired) {
+			return err
+		}
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) {
+			switch {
+			case apiErr.IsUnauthorized(), apiErr.IsForbidden():
+				return ctrl.renderAPIError(c, apiErr, "No tienes autorización para editar productos. Inicia sesión de nuevo.", "/login", "Iniciar sesión")
+			case apiErr.IsNotFound():
+				return ctrl.renderAPIError(c, apiErr, "El producto ya no existe.", "/admin/dashboard/product/register", "Volver a productos")
+			case apiErr.IsConflict():
+				return ctrl.renderProductsPage(c, payload, map[string]string{"product_name": "Ya existe un producto con ese nombre."})
+			case apiErr.IsValidation():
+				return ctrl.renderProductsPage(c, payload, formErrors(apiErr, productFields, "product_name"))
+			}
+		}
+		return err
 	}
+	ctrl.catalog.Invalidate()
 
+	flashSuccess(c, fmt.Sprintf("Actualizamos el producto %s.", cmp.Or(product.Product.Name, payload.Name)))
 	return c.Redirect(http.StatusSeeOther, "/admin/dashboard/product/register")
 }
 
-func CategoryPage(c echo.Context) error {
+func (ctrl Controller) CategoryPage(c echo.Context) error {
 	token := csrf.Token(c.Request())
 
 	c.Response().Header().Set("Cache-Control", "no-store")
-	return views.RegisterCategory("Alejandrinas - Registro de Categorias", token).
-		Render(renderArgs(c))
+	return views.RegisterCategory("Alejandrinas - Registro de Categorias", token, dtos.CreateCategoryForm{}, nil).
+		Render(ctrl.renderArgs(c))
+}
+
+func (ctrl Controller) renderCategoryErrors(c echo.Context, form dtos.CreateCategoryForm, errs map[string]string) error {
+	c.Response().WriteHeader(http.StatusUnprocessableEntity)
+	return views.RegisterCategory("Alejandrinas - Registro de Categorias", csrf.Token(c.Request()), form, errs).
+		Render(ctrl.renderArgs(c))
+}
+
+// validateProduct checks a product submission against the current
+// categories. It returns nil errors when the form is valid.
+func (ctrl Controller) validateProduct(c echo.Context, form dtos.CreateProductForm) (map[string]string, error) {
+	categories, err := ctrl.client.GetAllCategories(c.Request().Context())
+	if err != nil {
+		return nil, err
+	}
+
+	v := validator.New()
+	if form.Validate(v, categories.Categories); !v.Valid() {
+		return v.Errors, nil
+	}
+
+	return nil, nil
 }
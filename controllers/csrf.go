@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/csrf"
+	"github.com/labstack/echo/v4"
+	"github.com/tikimcrzx723/alejandrinasweb/routes/contexts"
+)
+
+const csrfExpiredMsg = "Tu sesión expiró, vuelve a intentarlo."
+
+// CSRFFailed is the csrf.ErrorHandler. It logs why the check failed, never
+// the tokens or cookies involved, and hands the request to HTTPErrorHandler.
+func CSRFFailed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	reason := csrf.FailureReason(r)
+	slog.WarnContext(ctx, "csrf check failed",
+		"reason", reason,
+		"method", r.Method,
+		"path", r.URL.Path,
+		"origin", r.Header.Get("Origin"),
+		"request_id", contexts.ExtractRequestID(ctx),
+	)
+
+	c := contexts.ExtractApp(ctx).Context
+	if c == nil {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	// r carries the fresh token csrf.Protect issued along with the failure.
+	c.SetRequest(r)
+	c.Error(fmt.Errorf("%w: %w", ErrCSRF, reason))
+}
+
+// csrfRetry asks the user to send again a form whose token was missing or
+// stale. Browsers go back to the form, which is rendered with a fresh token;
+// htmx reloads the page and scripts get the new token in X-CSRF-Token.
+func (ctrl Controller) csrfRetry(c echo.Context) error {
+	c.Response().Header().Set("X-CSRF-Token", csrf.Token(c.Request()))
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		flashWarning(c, csrfExpiredMsg)
+		c.Response().Header().Set("HX-Refresh", "true")
+		return JSONError(c, http.StatusForbidden, csrfExpiredMsg)
+	}
+	if WantsStatus(c) {
+		return JSONError(c, http.StatusForbidden, csrfExpiredMsg)
+	}
+
+	back := localPath(ReturnPath(c), "")
+	if back == "" {
+		return ctrl.renderErrorPage(c, http.StatusForbidden, pageCSRF)
+	}
+
+	flashWarning(c, csrfExpiredMsg)
+	return c.Redirect(http.StatusSeeOther, back)
+}
@@ -0,0 +1,234 @@
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/api"
+	"github.com/tikimcrzx723/alejandrinasweb/routes/contexts"
+	"github.com/tikimcrzx723/alejandrinasweb/views"
+)
+
+// ErrCSRF is wrapped around the reason a request failed the CSRF check.
+var ErrCSRF = errors.New("csrf check failed")
+
+// errorPage is what the user is told about a failed request.
+type errorPage struct {
+	title     string
+	msg       string
+	link      string
+	linkTitle string
+}
+
+var (
+	pageNotFound = errorPage{
+		title:     "Oops! Pagina no encontrada",
+		msg:       "La página que busca no está disponible o ha sido trasladada. Pruebe con otra página o vaya a la página de inicio con el botón de abajo.",
+		link:      "/",
+		linkTitle: "Ir a la página de inicio",
+	}
+	pageForbidden = errorPage{
+		title:     "No tienes acceso a esta página",
+		msg:       "Tu cuenta no tiene permiso para ver esta sección. Si crees que es un error, contáctanos.",
+		link:      "/",
+		linkTitle: "Ir a la página de inicio",
+	}
+	pageCSRF = errorPage{
+		title:     "Tu sesión expiró",
+		msg:       "Por seguridad, el formulario caducó antes de enviarse. Vuelve a la página e inténtalo de nuevo.",
+		link:      "/",
+		linkTitle: "Volver a intentarlo",
+	}
+	pageUnavailable = errorPage{
+		title:     "La tienda no está disponible",
+		msg:       "No pudimos conectar con la tienda. Intenta de nuevo en unos minutos.",
+		link:      "/",
+		linkTitle: "Ir a la página de inicio",
+	}
+	pageBadRequest = errorPage{
+		title:     "No pudimos procesar tu solicitud",
+		msg:       "La solicitud no es válida. Revisa los datos e inténtalo de nuevo.",
+		link:      "/",
+		linkTitle: "Ir a la página de inicio",
+	}
+	pageInternal = errorPage{
+		title:     "Algo salió mal",
+		msg:       "Ocurrió un error inesperado y ya lo estamos revisando. Intenta de nuevo en unos minutos.",
+		link:      "/",
+		linkTitle: "Ir a la página de inicio",
+	}
+)
+
+// statusPages holds the page for every status shown as is. Other client
+// errors get pageBadRequest and other server errors pageInternal.
+var statusPages = map[int]errorPage{
+	http.StatusUnauthorized: {
+		title:     "Inicia sesión para continuar",
+		msg:       "Necesitas iniciar sesión para ver esta página.",
+		link:      "/login",
+		linkTitle: "Iniciar sesión",
+	},
+	http.StatusForbidden:        pageForbidden,
+	http.StatusNotFound:         pageNotFound,
+	http.StatusMethodNotAllowed: pageNotFound,
+	http.StatusRequestEntityTooLarge: {
+		title:     "El archivo es demasiado grande",
+		msg:       "Lo que intentaste subir supera el tamaño permitido. Reduce el archivo e inténtalo de nuevo.",
+		link:      "/",
+		linkTitle: "Ir a la página de inicio",
+	},
+	http.StatusTooManyRequests: {
+		title:     "Demasiadas solicitudes",
+		msg:       "Espera un momento antes de volver a intentarlo.",
+		link:      "/",
+		linkTitle: "Ir a la página de inicio",
+	},
+	http.StatusBadGateway:         pageUnavailable,
+	http.StatusServiceUnavailable: pageUnavailable,
+	http.StatusGatewayTimeout:     pageUnavailable,
+}
+
+// HTTPErrorHandler answers every request a handler or middleware failed. The
+// error is logged with the request ID, which browsers see on a branded error
+// page and scripts, htmx included, in a JSON error envelope.
+func (ctrl Controller) HTTPErrorHandler(err error, c echo.Context) {
+	ctx := c.Request().Context()
+	status, page := classifyError(err)
+
+	attrs := []any{
+		"err", err,
+		"status", status,
+		"method", c.Request().Method,
+		"path", c.Request().URL.Path,
+		"request_id", contexts.ExtractRequestID(ctx),
+	}
+	if status >= http.StatusInternalServerError {
+		slog.ErrorContext(ctx, "request failed", attrs...)
+	} else {
+		slog.DebugContext(ctx, "request failed", attrs...)
+	}
+
+	if c.Response().Committed {
+		return
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	if errors.Is(err, ErrCSRF) {
+		err = ctrl.csrfRetry(c)
+	} else if c.Request().Method == http.MethodHead {
+		err = c.NoContent(status)
+	} else if WantsStatus(c) {
+		err = JSONError(c, status, page.msg)
+	} else {
+		err = ctrl.renderErrorPage(c, status, page)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "could not render error page", "err", err, "request_id", contexts.ExtractRequestID(ctx))
+	}
+}
+
+// classifyError picks the status code and page for err.
+func classifyError(err error) (int, errorPage) {
+	if errors.Is(err, ErrCSRF) {
+		return http.StatusForbidden, pageCSRF
+	}
+	if errors.Is(err, api.ErrUnavailable) {
+		return http.StatusServiceUnavailable, pageUnavailable
+	}
+
+	status := http.StatusInternalServerError
+	var httpErr *echo.HTTPError
+	var apiErr *api.Error
+	switch {
+	case errors.As(err, &httpErr):
+		status = httpErr.Code
+	case errors.As(err, &apiErr):
+		status = apiErr.StatusCode
+		if status < http.StatusBadRequest || status >= http.StatusInternalServerError {
+			status = http.StatusBadGateway
+		}
+	}
+
+	if page, ok := statusPages[status]; ok {
+		return status, page
+	}
+	if status < http.StatusInternalServerError {
+		return status, pageBadRequest
+	}
+
+	return status, pageInternal
+}
+
+func (ctrl Controller) renderErrorPage(c echo.Context, status int, page errorPage) error {
+	c.Response().WriteHeader(status)
+	return views.ErrorPage(
+		views.WithErrPageTitle(page.title),
+		views.WithErrPageMsg(page.msg),
+		views.WithErrPageLink(page.link, page.linkTitle),
+	).Render(ctrl.renderArgs(c))
+}
+
+// WantsStatus reports whether the caller is a script, an htmx request or a
+// JSON client, that expects a status code rather than a redirect or a page.
+func WantsStatus(c echo.Context) bool {
+	r := c.Request()
+	return r.Header.Get("HX-Request") == "true" ||
+		strings.Contains(r.Header.Get(echo.HeaderAccept), echo.MIMEApplicationJSON)
+}
+
+// JSONError writes the error envelope JSON clients get for a failed request.
+func JSONError(c echo.Context, status int, msg string) error {
+	return c.JSON(status, map[string]any{
+		"error": map[string]any{
+			"status":     status,
+			"message":    msg,
+			"request_id": contexts.ExtractRequestID(c.Request().Context()),
+		},
+	})
+}
+
+// renderAPIError shows msg on the error page with a link back to where the
+// user came from, using the backend status when it is a client error.
+func (ctrl Controller) renderAPIError(
+	c echo.Context,
+	apiErr *api.Error,
+	msg string,
+	link string,
+	linkTitle string,
+) error {
+	status := apiErr.StatusCode
+	if status < http.StatusBadRequest || status >= http.StatusInternalServerError {
+		status = http.StatusBadGateway
+	}
+
+	c.Response().WriteHeader(status)
+	return views.ErrorPage(
+		views.WithErrPageTitle("No pudimos completar tu solicitud"),
+		views.WithErrPageMsg(msg),
+		views.WithErrPageLink(link, linkTitle),
+	).Render(ctrl.renderArgs(c))
+}
+
+// formErrors turns a backend validation error into form field errors. fields
+// maps the backend's field names to form input names; unmapped fields, and
+// errors without field details, are reported under fallback.
+func formErrors(apiErr *api.Error, fields map[string]string, fallback string) map[string]string {
+	errs := make(map[string]string, len(apiErr.Fields)+1)
+	for field, msg := range apiErr.Fields {
+		key, ok := fields[field]
+		if !ok {
+			key = fallback
+		}
+		if _, exists := errs[key]; !exists {
+			errs[key] = msg
+		}
+	}
+	if len(errs) == 0 {
+		errs[fallback] = apiErr.Message
+	}
+
+	return errs
+}
@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/sessions"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+	"github.com/tikimcrzx723/alejandrinasweb/routes/contexts"
+)
+
+// Flash messages stay in the flash session until a page that shows them is
+// rendered, so a message queued before a redirect reaches the page after it.
+
+// addFlash queues a message shown on the next page the user sees.
+func addFlash(ctx echo.Context, flashType contexts.FlashType, msg string) error {
+	s, err := session.Get(flashSessionName, ctx)
+	if err != nil {
+		return err
+	}
+
+	s.AddFlash(contexts.FlashMessage{
+		ID:        uuid.New(),
+		Type:      flashType,
+		CreatedAt: time.Now(),
+		Message:   msg,
+	}, flashSessionName)
+
+	if err := s.Save(ctx.Request(), ctx.Response()); err != nil {
+		return err
+	}
+
+	// A page rendered by this same request shows it too.
+	ctx.Set(contexts.FlashKey{}.String(), pendingFlashes(s))
+
+	return nil
+}
+
+func flashSuccess(c echo.Context, msg string) { flash(c, contexts.FlashSuccess, msg) }
+func flashError(c echo.Context, msg string)   { flash(c, contexts.FlashError, msg) }
+func flashWarning(c echo.Context, msg string) { flash(c, contexts.FlashWarning, msg) }
+func flashInfo(c echo.Context, msg string)    { flash(c, contexts.FlashInfo, msg) }
+
+// flash is addFlash for handlers that have already done their work: a flash
+// that cannot be saved is logged rather than failing the request.
+func flash(c echo.Context, flashType contexts.FlashType, msg string) {
+	if err := addFlash(c, flashType, msg); err != nil {
+		slog.ErrorContext(c.Request().Context(), "could not add flash message", "err", err)
+	}
+}
+
+// pendingFlashes returns the flash messages in s without removing them.
+func pendingFlashes(s *sessions.Session) []contexts.FlashMessage {
+	raw, _ := s.Values[flashSessionName].([]any)
+
+	flashMessages := make([]contexts.FlashMessage, 0, len(raw))
+	for _, flash := range raw {
+		if msg, ok := flash.(contexts.FlashMessage); ok {
+			flashMessages = append(flashMessages, msg)
+		}
+	}
+
+	return flashMessages
+}
+
+func RegisterFlashMessageContext(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if strings.HasPrefix(c.Request().URL.Path, "/static") {
+			return next(c)
+		}
+
+		s, err := session.Get(flashSessionName, c)
+		if err != nil {
+			return next(c)
+		}
+
+		c.Set(contexts.FlashKey{}.String(), pendingFlashes(s))
+
+		return next(c)
+	}
+}
+
+// consumeFlashes removes the request's flash messages from the session just
+// before a page shows them. Once the response has started the cookie can no
+// longer change, so the messages are kept for the next page instead.
+func consumeFlashes(c echo.Context) {
+	flashes, _ := c.Get(contexts.FlashKey{}.String()).([]contexts.FlashMessage)
+	if len(flashes) == 0 {
+		return
+	}
+
+	if c.Response().Committed {
+		c.Set(contexts.FlashKey{}.String(), []contexts.FlashMessage{})
+		return
+	}
+
+	s, err := session.Get(flashSessionName, c)
+	if err != nil {
+		return
+	}
+
+	s.Flashes(flashSessionName)
+	if err := s.Save(c.Request(), c.Response()); err != nil {
+		slog.ErrorContext(c.Request().Context(), "could not save flash session", "err", err)
+		c.Set(contexts.FlashKey{}.String(), []contexts.FlashMessage{})
+	}
+}
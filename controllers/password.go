@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/api"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/mailer"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/validator"
+	"github.com/tikimcrzx723/alejandrinasweb/views"
+)
+
+// tokenURL returns the link, sent by email, that carries token to path.
+func (ctrl Controller) tokenURL(path string, token string) string {
+	return ctrl.cfg.AppURL + path + "?" + url.Values{"token": {token}}.Encode()
+}
+
+func (ctrl Controller) ForgotPasswordPage(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return views.ForgotPasswordPage("Alejandrinas - Recuperar contraseña", dtos.ForgotPasswordForm{}, nil).
+		Render(ctrl.renderArgs(c))
+}
+
+// SendPasswordReset emails a reset link. The response is the same whether or
+// not the address has an account, so the form cannot be used to find users.
+func (ctrl Controller) SendPasswordReset(c echo.Context) error {
+	var payload dtos.ForgotPasswordForm
+	if err := c.Bind(&payload); err != nil {
+		return err
+	}
+	payload.Email = strings.TrimSpace(payload.Email)
+
+	v := validator.New()
+	if payload.Validate(v); !v.Valid() {
+		c.Response().WriteHeader(http.StatusUnprocessableEntity)
+		return views.ForgotPasswordPage("Alejandrinas - Recuperar contraseña", payload, v.Errors).
+			Render(ctrl.renderArgs(c))
+	}
+
+	ctx := c.Request().Context()
+	token, err := ctrl.client.CreateToken(ctx, dtos.CreateTokenRequest{
+		Email: payload.Email,
+		Scope: dtos.ScopePasswordReset,
+	})
+	if err != nil {
+		var apiErr *api.Error
+		if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+			return err
+		}
+	} else {
+		msg := mailer.Message{
+			To:      payload.Email,
+			Subject: "Restablece tu contraseña",
+			Text: fmt.Sprintf(
+				"Recibimos una solicitud para restablecer tu contraseña en Alejandrinas.\n\n"+
+					"Abre este enlace para elegir una nueva:\n%s\n\n"+
+					"El enlace se puede usar una sola vez y vence el %s.\n"+
+					"Si no lo solicitaste, ignora este correo.\n",
+				ctrl.tokenURL("/reset-password", token.Token.Plaintext),
+				token.Token.ExpiresAt().Format("02/01/2006 15:04 MST"),
+			),
+		}
+		if err := ctrl.mailer.Send(ctx, msg); err != nil {
+			slog.ErrorContext(ctx, "could not send password reset email", "err", err)
+		}
+	}
+
+	return views.ForgotPasswordSentPage("Alejandrinas - Revisa tu correo", payload.Email).
+		Render(ctrl.renderArgs(c))
+}
+
+func (ctrl Controller) ResetPasswordPage(c echo.Context) error {
+	token := c.QueryParam("token")
+	if token == "" {
+		return ctrl.renderInvalidResetLink(c)
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return views.ResetPasswordPage("Alejandrinas - Nueva contraseña", dtos.ResetPasswordForm{Token: token}, nil).
+		Render(ctrl.renderArgs(c))
+}
+
+func (ctrl Controller) ResetPassword(c echo.Context) error {
+	var payload dtos.ResetPasswordForm
+	if err := c.Bind(&payload); err != nil {
+		return err
+	}
+
+	v := validator.New()
+	if payload.Validate(v); !v.Valid() {
+		if _, ok := v.Errors["token"]; ok {
+			return ctrl.renderInvalidResetLink(c)
+		}
+
+		c.Response().WriteHeader(http.StatusUnprocessableEntity)
+		return views.ResetPasswordPage("Alejandrinas - Nueva contraseña", dtos.ResetPasswordForm{Token: payload.Token}, v.Errors).
+			Render(ctrl.renderArgs(c))
+	}
+
+	ctx := c.Request().Context()
+	resp, err := ctrl.client.ResetPassword(ctx, dtos.ResetPasswordRequest{
+		Token:    payload.Token,
+		Password: payload.Password,
+	})
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) {
+			switch {
+			case apiErr.Code == api.CodeInvalidToken, apiErr.IsNotFound(), apiErr.IsUnauthorized():
+				return ctrl.renderInvalidResetLink(c)
+			case apiErr.IsValidation():
+				c.Response().WriteHeader(http.StatusUnprocessableEntity)
+				return views.ResetPasswordPage(
+					"Alejandrinas - Nueva contraseña",
+					dtos.ResetPasswordForm{Token: payload.Token},
+					map[string]string{"password": apiErr.Message},
+				).Render(ctrl.renderArgs(c))
+			}
+		}
+		return err
+	}
+
+	// The backend has revoked the user's refresh tokens; end the sessions
+	// kept here as well, this one included.
+	ctrl.revokeUserSessions(ctx, resp.User.ID)
+	if err := clearUserSession(c); err != nil {
+		return err
+	}
+
+	flashSuccess(c, "Tu contraseña fue actualizada. Inicia sesión con la nueva.")
+
+	return c.Redirect(http.StatusSeeOther, "/login")
+}
+
+// revokeUserSessions logs userID out of every stored session. Cookie sessions
+// cannot be reached, but they end once their revoked refresh token is used.
+func (ctrl Controller) revokeUserSessions(ctx context.Context, userID int) {
+	if ctrl.sessions == nil {
+		return
+	}
+	if userID <= 0 {
+		slog.WarnContext(ctx, "password reset did not name the user; stored sessions were kept")
+		return
+	}
+
+	n, err := ctrl.sessions.RevokeUserSessions(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not revoke sessions after password reset", "user_id", userID, "err", err)
+		return
+	}
+	slog.InfoContext(ctx, "revoked sessions after password reset", "user_id", userID, "count", n)
+}
+
+func (ctrl Controller) renderInvalidResetLink(c echo.Context) error {
+	c.Response().WriteHeader(http.StatusBadRequest)
+	return views.ErrorPage(
+		views.WithErrPageTitle("Enlace no válido"),
+		views.WithErrPageMsg("El enlace para restablecer tu contraseña venció o ya fue usado."),
+		views.WithErrPageLink("/forgot-password", "Solicitar otro enlace"),
+	).Render(ctrl.renderArgs(c))
+}
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode"
+
+	"github.com/labstack/echo/v4"
+)
+
+// localPath returns next when it is a path on this site and fallback
+// otherwise, so form redirects cannot be pointed at another host. Browsers
+// treat a backslash like a slash and drop tabs and newlines, so both are
+// refused too.
+func localPath(next, fallback string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") {
+		return fallback
+	}
+	if strings.ContainsFunc(next, func(r rune) bool { return r == '\\' || unicode.IsControl(r) }) {
+		return fallback
+	}
+
+	u, err := url.Parse(next)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return fallback
+	}
+
+	return next
+}
+
+// LoginURL returns the login page that sends the user back to next once they
+// log in. An unsafe next is dropped.
+func LoginURL(next string) string {
+	next = localPath(next, "")
+	if next == "" || next == "/" {
+		return "/login"
+	}
+
+	return "/login?" + url.Values{"next": {next}}.Encode()
+}
+
+// ReturnPath is the page a user sent to the login page should come back to:
+// the page itself for a GET, and the page the request came from otherwise,
+// since a form submission cannot be replayed.
+func ReturnPath(c echo.Context) string {
+	r := c.Request()
+	if r.Header.Get("HX-Request") == "true" {
+		return sameHostPath(r, r.Header.Get("HX-Current-URL"))
+	}
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return r.URL.RequestURI()
+	}
+
+	return sameHostPath(r, r.Referer())
+}
+
+// sameHostPath returns the path and query of rawURL when it points at the
+// host serving r.
+func sameHostPath(r *http.Request, rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host != r.Host {
+		return ""
+	}
+
+	return u.RequestURI()
+}
+
+// Forbidden tells a logged-in user that their account cannot open the page.
+func (ctrl Controller) Forbidden(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return ctrl.renderErrorPage(c, http.StatusForbidden, pageForbidden)
+}
+
+// LoginRedirect is where a successful login goes: next when it is safe, the
+// home page otherwise.
+func LoginRedirect(next string) string {
+	return localPath(next, "/")
+}
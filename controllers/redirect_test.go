@@ -0,0 +1,79 @@
+package controllers
+
+import "testing"
+
+func TestLocalPath(t *testing.T) {
+	tests := []struct {
+		name string
+		next string
+		want string
+	}{
+		{"root", "/", "/"},
+		{"path", "/account/orders", "/account/orders"},
+		{"path with query", "/search?q=crema&page=2", "/search?q=crema&page=2"},
+		{"path with fragment", "/product/crema-1#reviews", "/product/crema-1#reviews"},
+		{"empty", "", "/fallback"},
+		{"relative", "account", "/fallback"},
+		{"absolute URL", "https://evil.example/", "/fallback"},
+		{"scheme relative", "//evil.example/", "/fallback"},
+		{"javascript", "javascript:alert(1)", "/fallback"},
+		{"backslash", "/\\evil.example", "/fallback"},
+		{"leading backslash", "\\\\evil.example", "/fallback"},
+		{"tab", "/\t/evil.example", "/fallback"},
+		{"newline", "/\n/evil.example", "/fallback"},
+		{"invalid escape", "/%zz", "/fallback"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := localPath(tt.next, "/fallback"); got != tt.want {
+				t.Errorf("localPath(%q) = %q, want %q", tt.next, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoginURL(t *testing.T) {
+	tests := []struct {
+		name string
+		next string
+		want string
+	}{
+		{"empty", "", "/login"},
+		{"home", "/", "/login"},
+		{"path", "/account/orders", "/login?next=%2Faccount%2Forders"},
+		{"path with query", "/search?q=crema", "/login?next=%2Fsearch%3Fq%3Dcrema"},
+		{"absolute URL", "https://evil.example/", "/login"},
+		{"scheme relative", "//evil.example/", "/login"},
+		{"backslash", "/\\evil.example", "/login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LoginURL(tt.next); got != tt.want {
+				t.Errorf("LoginURL(%q) = %q, want %q", tt.next, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoginRedirect(t *testing.T) {
+	tests := []struct {
+		name string
+		next string
+		want string
+	}{
+		{"empty", "", "/"},
+		{"path", "/checkout", "/checkout"},
+		{"absolute URL", "http://evil.example/checkout", "/"},
+		{"scheme relative", "//evil.example", "/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LoginRedirect(tt.next); got != tt.want {
+				t.Errorf("LoginRedirect(%q) = %q, want %q", tt.next, got, tt.want)
+			}
+		})
+	}
+}
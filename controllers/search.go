@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/search"
+	"github.com/tikimcrzx723/alejandrinasweb/views"
+)
+
+const (
+	searchResultsPerPage = 12
+	// The backend has no text search, so the catalog is fetched page by page
+	// and matched here. searchCatalogPages caps how many pages one search
+	// reads.
+	searchCatalogPageSize = 500
+	searchCatalogPages    = 20
+	// searchCatalogTTL is how long the catalog read for searches is reused
+	// before it is read again.
+	searchCatalogTTL = 5 * time.Minute
+)
+
+func (ctrl Controller) Search(c echo.Context) error {
+	var form dtos.SearchForm
+	if err := c.Bind(&form); err != nil {
+		return err
+	}
+	form.Page = queryPage(c)
+
+	products, complete, err := ctrl.catalog.Search(c.Request().Context(), form)
+	if err != nil {
+		return err
+	}
+
+	results, meta := search.Page(products, form.Page, searchResultsPerPage)
+
+	return views.SearchPage("Alejandrinas - Búsqueda", results, meta, form, complete).
+		Render(ctrl.renderArgs(c))
+}
+
+// readCatalog returns the active products, reading every page of the catalog
+// up to searchCatalogPages. complete is false when the catalog had more
+// products than were read.
+func (ctrl Controller) readCatalog(ctx context.Context) ([]dtos.Product, bool, error) {
+	active := true
+	query := dtos.ProductQuery{Active: &active, Limit: searchCatalogPageSize}
+
+	var products []dtos.Product
+	for page := 1; ; page++ {
+		query.Page = page
+		resp, err := ctrl.client.GetProducts(ctx, query)
+		if err != nil {
+			return nil, false, err
+		}
+		products = append(products, resp.Product...)
+
+		if page >= resp.Meta.TotalPages || len(resp.Product) == 0 {
+			return products, true, nil
+		}
+		if page == searchCatalogPages {
+			slog.WarnContext(ctx, "search read only part of the catalog",
+				"read", len(products),
+				"total", resp.Meta.Total,
+			)
+			return products, false, nil
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/sessions"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/sessionstore"
+	"github.com/tikimcrzx723/alejandrinasweb/routes/contexts"
+	"github.com/tikimcrzx723/alejandrinasweb/views"
+)
+
+// SessionManager lists and revokes the sessions of a user. It is only
+// available when sessions are kept on the server.
+type SessionManager interface {
+	Regenerate(ctx context.Context, s *sessions.Session) error
+	UserSessions(ctx context.Context, userID int) ([]sessionstore.Record, error)
+	RevokeSession(ctx context.Context, userID int, id string) error
+	RevokeOtherSessions(ctx context.Context, userID int, keepID string) (int64, error)
+	RevokeUserSessions(ctx context.Context, userID int) (int64, error)
+}
+
+var _ SessionManager = (*sessionstore.Store)(nil)
+
+// SessionUserID returns the logged-in user of an auth session, or 0.
+func SessionUserID(s *sessions.Session) int {
+	if isAuth, _ := s.Values[AuthUserAuthenticated].(bool); !isAuth {
+		return 0
+	}
+
+	switch v := s.Values[authUserIDKey].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+
+	return 0
+}
+
+// currentSessionID returns the ID of the auth session of the request.
+func currentSessionID(c echo.Context) string {
+	s, err := session.Get(AuthSessionName, c)
+	if err != nil {
+		return ""
+	}
+
+	return s.ID
+}
+
+func (ctrl Controller) AccountSessionsPage(c echo.Context) error {
+	if ctrl.sessions == nil {
+		return echo.ErrNotFound
+	}
+
+	userID := contexts.ExtractApp(c.Request().Context()).UserID
+	records, err := ctrl.sessions.UserSessions(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	current := currentSessionID(c)
+	userSessions := make([]dtos.UserSession, 0, len(records))
+	for _, rec := range records {
+		userSessions = append(userSessions, dtos.UserSession{
+			ID:        rec.ID,
+			UserAgent: rec.UserAgent,
+			IP:        rec.IP,
+			CreatedAt: rec.CreatedAt,
+			LastSeen:  rec.UpdatedAt,
+			Current:   rec.ID == current,
+		})
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return views.AccountSessionsPage("Alejandrinas - Sesiones activas", userSessions).
+		Render(ctrl.renderArgs(c))
+}
+
+// RevokeSession logs out one of the user's sessions. Revoking the current
+// one is a regular logout.
+func (ctrl Controller) RevokeSession(c echo.Context) error {
+	if ctrl.sessions == nil {
+		return echo.ErrNotFound
+	}
+
+	id := c.Param("id")
+	if id == currentSessionID(c) {
+		return LogoutUser(c)
+	}
+
+	userID := contexts.ExtractApp(c.Request().Context()).UserID
+	err := ctrl.sessions.RevokeSession(c.Request().Context(), userID, id)
+	switch {
+	case errors.Is(err, sessionstore.ErrNotFound):
+		flashWarning(c, "Esa sesión ya estaba cerrada.")
+	case err != nil:
+		return err
+	default:
+		flashSuccess(c, "Cerraste la sesión en ese dispositivo.")
+	}
+
+	return c.Redirect(http.StatusSeeOther, "/account/sessions")
+}
+
+// RevokeOtherSessions logs the user out everywhere except here.
+func (ctrl Controller) RevokeOtherSessions(c echo.Context) error {
+	if ctrl.sessions == nil {
+		return echo.ErrNotFound
+	}
+
+	userID := contexts.ExtractApp(c.Request().Context()).UserID
+	n, err := ctrl.sessions.RevokeOtherSessions(c.Request().Context(), userID, currentSessionID(c))
+	if err != nil {
+		return err
+	}
+
+	switch n {
+	case 0:
+		flashInfo(c, "No había otras sesiones abiertas.")
+	case 1:
+		flashSuccess(c, "Cerraste 1 sesión en otro dispositivo.")
+	default:
+		flashSuccess(c, fmt.Sprintf("Cerraste %d sesiones en otros dispositivos.", n))
+	}
+
+	return c.Redirect(http.StatusSeeOther, "/account/sessions")
+}
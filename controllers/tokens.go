@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/labstack/echo-contrib/session"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/api"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+	"github.com/tikimcrzx723/alejandrinasweb/routes/contexts"
+)
+
+// SessionTokens renews backend tokens for the api client. Tokens are kept in
+// the auth session, which the cookie store encrypts.
+type SessionTokens struct{}
+
+var _ api.TokenRefresher = SessionTokens{}
+
+func (SessionTokens) RefreshToken(ctx context.Context) string {
+	c := contexts.ExtractApp(ctx).Context
+	if c == nil {
+		return ""
+	}
+
+	s, err := session.Get(AuthSessionName, c)
+	if err != nil {
+		return ""
+	}
+
+	token, _ := s.Values[authRefreshTokenKey].(string)
+	return token
+}
+
+func (SessionTokens) TokensRefreshed(ctx context.Context, tokens dtos.TokenPair) error {
+	c := contexts.ExtractApp(ctx).Context
+	if c == nil {
+		return nil
+	}
+
+	s, err := session.Get(AuthSessionName, c)
+	if err != nil {
+		return err
+	}
+
+	s.Values["TOKEN_KEY"] = tokens.AccessToken
+	if tokens.RefreshToken != "" {
+		s.Values[authRefreshTokenKey] = tokens.RefreshToken
+	}
+
+	if err := saveAuthSession(c, s); err != nil {
+		return err
+	}
+
+	// Later backend calls in this request still carry the old token in
+	// their context.
+	c.Set(contexts.RenewedTokenKey{}.String(), tokens.AccessToken)
+
+	return nil
+}
+
+func (SessionTokens) RefreshFailed(ctx context.Context) {
+	c := contexts.ExtractApp(ctx).Context
+	if c == nil {
+		return
+	}
+
+	if err := clearUserSession(c); err != nil {
+		slog.ErrorContext(ctx, "could not clear expired session", "err", err)
+	}
+
+	flashWarning(c, "Tu sesión expiró. Inicia sesión de nuevo.")
+}
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/api"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+	"github.com/tikimcrzx723/alejandrinasweb/routes/contexts"
+	"github.com/tikimcrzx723/alejandrinasweb/views"
+)
+
+const (
+	wishlistSessionName = "wishlistSession"
+	wishlistItemsKey    = "WISHLIST_ITEMS"
+)
+
+// loadWishlist returns the wishlist stored in the session. As with the cart,
+// logged-in users keep a copy of their backend list there so product cards
+// can show the heart state without an API call.
+func loadWishlist(c echo.Context) dtos.Wishlist {
+	s, err := session.Get(wishlistSessionName, c)
+	if err != nil {
+		return dtos.Wishlist{}
+	}
+
+	items, _ := s.Values[wishlistItemsKey].([]dtos.WishlistItem)
+	return dtos.Wishlist{Items: items}
+}
+
+func saveWishlist(c echo.Context, wishlist dtos.Wishlist) error {
+	s, err := session.Get(wishlistSessionName, c)
+	if err != nil {
+		return err
+	}
+
+	s.Values[wishlistItemsKey] = wishlist.Items
+
+	return s.Save(c.Request(), c.Response())
+}
+
+// mergeGuestWishlist moves the products a guest picked into the wishlist of
+// the user that just logged in.
+func (ctrl Controller) mergeGuestWishlist(c echo.Context, token string) {
+	ctx := withToken(c.Request().Context(), token)
+
+	guest := loadWishlist(c)
+	resp, err := ctrl.client.MergeWishlist(ctx, guest.Items)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not merge guest wishlist", "err", err)
+		return
+	}
+
+	if err := saveWishlist(c, resp.Wishlist); err != nil {
+		slog.ErrorContext(ctx, "could not save wishlist session", "err", err)
+	}
+}
+
+func (ctrl Controller) WishlistPage(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	resp, err := ctrl.client.GetWishlist(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := saveWishlist(c, resp.Wishlist); err != nil {
+		slog.ErrorContext(ctx, "could not save wishlist session", "err", err)
+	}
+
+	products := make([]dtos.Product, 0, len(resp.Wishlist.Items))
+	for _, item := range resp.Wishlist.Items {
+		product, err := ctrl.client.GetProductBySKU(ctx, item.SKU)
+		if err != nil {
+			var apiErr *api.Error
+			if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+				slog.ErrorContext(ctx, "could not load wishlist item", "sku", item.SKU, "err", err)
+			}
+			continue
+		}
+		products = append(products, product.Product)
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return views.WishlistPage("Alejandrinas - Favoritos", products).
+		Render(ctrl.renderArgs(c))
+}
+
+func (ctrl Controller) ToggleWishlistItem(c echo.Context) error {
+	var payload dtos.ToggleWishlistForm
+	if err := c.Bind(&payload); err != nil {
+		return err
+	}
+	next := localPath(payload.Next, "/")
+
+	ctx := c.Request().Context()
+	product, err := ctrl.client.GetProductBySKU(ctx, c.Param("sku"))
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+			return ctrl.renderAPIError(c, apiErr, "El producto que buscas no fue encontrado.", "/", "Seguir comprando")
+		}
+		return err
+	}
+
+	wishlist := loadWishlist(c)
+	item := dtos.WishlistItem{ProductID: product.Product.ID, SKU: product.Product.SKU}
+	removing := wishlist.Has(item.SKU)
+
+	if contexts.ExtractApp(ctx).IsAuthenticated {
+		var resp dtos.WishlistResponse
+		if removing {
+			resp, err = ctrl.client.RemoveWishlistItem(ctx, item.ProductID)
+		} else {
+			resp, err = ctrl.client.AddWishlistItem(ctx, item.ProductID)
+		}
+		if err != nil {
+			return err
+		}
+		wishlist = resp.Wishlist
+	} else {
+		wishlist.Toggle(item)
+	}
+
+	if err := saveWishlist(c, wishlist); err != nil {
+		return err
+	}
+
+	if removing {
+		flashInfo(c, fmt.Sprintf("Quitamos %s de tus favoritos.", product.Product.Name))
+	} else {
+		flashSuccess(c, fmt.Sprintf("Agregamos %s a tus favoritos.", product.Product.Name))
+	}
+
+	return c.Redirect(http.StatusSeeOther, next)
+}
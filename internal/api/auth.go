@@ -1,96 +1,78 @@
 package api
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
 	"net/http"
-	"strings"
-	"time"
 
 	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
 )
 
-func Login(ctx context.Context, baseURL string, req dtos.LoginRequest) (dtos.LoginResponse, error) {
-	if strings.TrimSpace(baseURL) == "" {
-		return dtos.LoginResponse{}, fmt.Errorf("baseURL is required")
-	}
-
-	url := strings.TrimRight(baseURL, "/") + "/auth/login"
-	body, err := json.Marshal(req)
-	if err != nil {
-		return dtos.LoginResponse{}, fmt.Errorf("marshal login request: %w", err)
+func (c *Client) Login(ctx context.Context, req dtos.LoginRequest) (dtos.LoginResponse, error) {
+	var loginResp dtos.LoginResponse
+	if err := c.doJSON(ctx, "login", http.MethodPost, "/auth/login", req, &loginResp); err != nil {
+		return dtos.LoginResponse{}, err
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
-	if err != nil {
-		return dtos.LoginResponse{}, fmt.Errorf("create login request: %w", err)
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
+	return loginResp, nil
+}
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(httpReq)
-	if err != nil {
-		return dtos.LoginResponse{}, fmt.Errorf("send login request: %w", err)
+func (c *Client) Register(ctx context.Context, req dtos.RegisterRequest) (dtos.RegisterResponse, error) {
+	var registerResp dtos.RegisterResponse
+	if err := c.doJSON(ctx, "register", http.MethodPost, "/auth/register", req, &registerResp); err != nil {
+		return dtos.RegisterResponse{}, err
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return dtos.LoginResponse{}, fmt.Errorf("read login response: %w", err)
-	}
+	return registerResp, nil
+}
 
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		return dtos.LoginResponse{}, fmt.Errorf("login failed (%d): %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
-	}
+// RefreshToken trades a refresh token for a new access token. It is never
+// retried itself when the backend answers 401.
+func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (dtos.RefreshTokenResponse, error) {
+	ctx = context.WithValue(ctx, noRefreshKey{}, true)
+	req := dtos.RefreshTokenRequest{RefreshToken: refreshToken}
 
-	var loginResp dtos.LoginResponse
-	if err := json.Unmarshal(respBody, &loginResp); err != nil {
-		return dtos.LoginResponse{}, fmt.Errorf("decode login response: %w", err)
+	var refreshResp dtos.RefreshTokenResponse
+	if err := c.doJSON(ctx, "refresh token", http.MethodPost, "/auth/refresh", req, &refreshResp); err != nil {
+		return dtos.RefreshTokenResponse{}, err
 	}
 
-	return loginResp, nil
+	return refreshResp, nil
 }
 
-func Register(ctx context.Context, baseURL string, req dtos.RegisterRequest) (dtos.RegisterResponse, error) {
-	if strings.TrimSpace(baseURL) == "" {
-		return dtos.RegisterResponse{}, fmt.Errorf("baseURL is required")
-	}
-
-	url := strings.TrimRight(baseURL, "/") + "/auth/register"
-	body, err := json.Marshal(req)
-	if err != nil {
-		return dtos.RegisterResponse{}, fmt.Errorf("marshal register request: %w", err)
-	}
+// ServiceKeyHeader carries the service key of the web app.
+const ServiceKeyHeader = "X-Service-Key"
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
-	if err != nil {
-		return dtos.RegisterResponse{}, fmt.Errorf("create register request: %w", err)
+// CreateToken asks the backend for a single-use token with the given scope
+// for the user with the given email. Anyone holding the token can act as the
+// user, so the call is authenticated with the service key.
+func (c *Client) CreateToken(ctx context.Context, req dtos.CreateTokenRequest) (dtos.TokenResponse, error) {
+	header := http.Header{}
+	if c.serviceKey != "" {
+		header.Set(ServiceKeyHeader, c.serviceKey)
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(httpReq)
-	if err != nil {
-		return dtos.RegisterResponse{}, fmt.Errorf("send register request: %w", err)
+	var tokenResp dtos.TokenResponse
+	if err := c.doJSONWithHeader(ctx, "create token", http.MethodPost, "/auth/tokens", header, req, &tokenResp); err != nil {
+		return dtos.TokenResponse{}, err
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return dtos.RegisterResponse{}, fmt.Errorf("read register response: %w", err)
-	}
+	return tokenResp, nil
+}
 
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		return dtos.RegisterResponse{}, fmt.Errorf("register failed (%d): %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+// ResetPassword sets a new password using a password-reset token. The
+// backend consumes the token, revokes every refresh token of the user and
+// returns the user.
+func (c *Client) ResetPassword(ctx context.Context, req dtos.ResetPasswordRequest) (dtos.ResetPasswordResponse, error) {
+	var resetResp dtos.ResetPasswordResponse
+	if err := c.doJSON(ctx, "reset password", http.MethodPut, "/auth/password", req, &resetResp); err != nil {
+		return dtos.ResetPasswordResponse{}, err
 	}
 
-	var registerResp dtos.RegisterResponse
-	if err := json.Unmarshal(respBody, &registerResp); err != nil {
-		return dtos.RegisterResponse{}, fmt.Errorf("decode register response: %w", err)
-	}
+	return resetResp, nil
+}
 
-	return registerResp, nil
+// ActivateUser marks the account that owns an activation token as verified.
+// The backend consumes the token.
+func (c *Client) ActivateUser(ctx context.Context, req dtos.ActivateUserRequest) error {
+	return c.doJSON(ctx, "activate user", http.MethodPut, "/users/activated", req, nil)
 }
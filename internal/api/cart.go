@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+)
+
+func (c *Client) GetCart(ctx context.Context) (dtos.CartResponse, error) {
+	var cartResp dtos.CartResponse
+	if err := c.doJSON(ctx, "get cart", http.MethodGet, "/cart", nil, &cartResp); err != nil {
+		return dtos.CartResponse{}, err
+	}
+
+	return cartResp, nil
+}
+
+func (c *Client) AddCartItem(ctx context.Context, item dtos.CartItem) (dtos.CartResponse, error) {
+	var cartResp dtos.CartResponse
+	if err := c.doJSON(ctx, "add cart item", http.MethodPost, "/cart/items", item, &cartResp); err != nil {
+		return dtos.CartResponse{}, err
+	}
+
+	return cartResp, nil
+}
+
+func (c *Client) UpdateCartItem(ctx context.Context, productID int, quantity int) (dtos.CartResponse, error) {
+	path := fmt.Sprintf("/cart/items/%d", productID)
+	req := dtos.UpdateCartItemRequest{Quantity: quantity}
+
+	var cartResp dtos.CartResponse
+	if err := c.doJSON(ctx, "update cart item", http.MethodPatch, path, req, &cartResp); err != nil {
+		return dtos.CartResponse{}, err
+	}
+
+	return cartResp, nil
+}
+
+func (c *Client) RemoveCartItem(ctx context.Context, productID int) (dtos.CartResponse, error) {
+	path := fmt.Sprintf("/cart/items/%d", productID)
+
+	var cartResp dtos.CartResponse
+	if err := c.doJSON(ctx, "remove cart item", http.MethodDelete, path, nil, &cartResp); err != nil {
+		return dtos.CartResponse{}, err
+	}
+
+	return cartResp, nil
+}
+
+// MergeCart adds the guest's items to the user's cart and returns the result.
+func (c *Client) MergeCart(ctx context.Context, items []dtos.CartItem) (dtos.CartResponse, error) {
+	req := dtos.MergeCartRequest{Items: items}
+
+	var cartResp dtos.CartResponse
+	if err := c.doJSON(ctx, "merge cart", http.MethodPost, "/cart/merge", req, &cartResp); err != nil {
+		return dtos.CartResponse{}, err
+	}
+
+	return cartResp, nil
+}
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+)
+
+// Service is the set of backend operations used by the web layer. Controllers
+// depend on it instead of *Client so a fake can be swapped in.
+type Service interface {
+	Login(ctx context.Context, req dtos.LoginRequest) (dtos.LoginResponse, error)
+	Register(ctx context.Context, req dtos.RegisterRequest) (dtos.RegisterResponse, error)
+	CreateToken(ctx context.Context, req dtos.CreateTokenRequest) (dtos.TokenResponse, error)
+	ResetPassword(ctx context.Context, req dtos.ResetPasswordRequest) (dtos.ResetPasswordResponse, error)
+	ActivateUser(ctx context.Context, req dtos.ActivateUserRequest) error
+
+	GetProducts(ctx context.Context, query dtos.ProductQuery) (dtos.ProductResponse, error)
+	GetProductBySKU(ctx context.Context, sku string) (dtos.SingleProductResponse, error)
+	CreateProduct(ctx context.Context, product dtos.CreateProductRequest) (dtos.SingleProductResponse, error)
+	AddProductImages(ctx context.Context, productID int, images []*multipart.FileHeader) (dtos.ProductImagesResponse, error)
+	UpdateProduct(ctx context.Context, id int, product dtos.UpdateProductRequest) (dtos.SingleProductResponse, error)
+
+	GetAllCategories(ctx context.Context) (dtos.CategoryResponse, error)
+	CreateCategory(ctx context.Context, category dtos.CreateCategoryRequest) (dtos.SingleCategoryResponse, error)
+
+	GetCart(ctx context.Context) (dtos.CartResponse, error)
+	AddCartItem(ctx context.Context, item dtos.CartItem) (dtos.CartResponse, error)
+	UpdateCartItem(ctx context.Context, productID int, quantity int) (dtos.CartResponse, error)
+	RemoveCartItem(ctx context.Context, productID int) (dtos.CartResponse, error)
+	MergeCart(ctx context.Context, items []dtos.CartItem) (dtos.CartResponse, error)
+
+	GetWishlist(ctx context.Context) (dtos.WishlistResponse, error)
+	AddWishlistItem(ctx context.Context, productID int) (dtos.WishlistResponse, error)
+	RemoveWishlistItem(ctx context.Context, productID int) (dtos.WishlistResponse, error)
+	MergeWishlist(ctx context.Context, items []dtos.WishlistItem) (dtos.WishlistResponse, error)
+
+	CreateOrder(ctx context.Context, order dtos.CreateOrderRequest, idempotencyKey string) (dtos.SingleOrderResponse, error)
+	GetOrder(ctx context.Context, id int) (dtos.SingleOrderResponse, error)
+	ListOrders(ctx context.Context, page, limit int) (dtos.OrderListResponse, error)
+	AdminListOrders(ctx context.Context, filter dtos.OrderFilter) (dtos.OrderListResponse, error)
+	AdminGetOrder(ctx context.Context, id int) (dtos.SingleOrderResponse, error)
+	UpdateOrderStatus(ctx context.Context, id int, req dtos.UpdateOrderStatusRequest) (dtos.SingleOrderResponse, error)
+	GetOrderHistory(ctx context.Context, id int) (dtos.OrderHistoryResponse, error)
+}
+
+var _ Service = (*Client)(nil)
+
+const defaultTimeout = 10 * time.Second
+
+// Client is a backend API client. It is safe for concurrent use and is meant
+// to be built once at startup and shared by every request.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	headers    http.Header
+	token      func(ctx context.Context) string
+	refresher  TokenRefresher
+	renewals   renewals
+	serviceKey string
+}
+
+type Option func(*Client)
+
+// WithTimeout sets the overall timeout of a single backend request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = timeout
+	}
+}
+
+// WithHTTPClient replaces the underlying http.Client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithHeader adds a header sent with every request.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.headers.Add(key, value)
+	}
+}
+
+// WithServiceKey sets the credential sent, in the ServiceKeyHeader, with the
+// calls the web app makes on its own behalf. It is never sent with other
+// requests.
+func WithServiceKey(key string) Option {
+	return func(c *Client) {
+		c.serviceKey = key
+	}
+}
+
+// WithTokenSource sets the function used to look up the bearer token for a
+// request. Requests without a token are sent unauthenticated.
+func WithTokenSource(token func(ctx context.Context) string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+func NewClient(baseURL string, opts ...Option) (*Client, error) {
+	if strings.TrimSpace(baseURL) == "" {
+		return nil, fmt.Errorf("baseURL is required")
+	}
+
+	u, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil {
+		return nil, fmt.Errorf("parse baseURL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("baseURL must be http or https, got %q", baseURL)
+	}
+
+	c := &Client{
+		baseURL: strings.TrimRight(u.String(), "/"),
+		httpClient: &http.Client{
+			Timeout:   defaultTimeout,
+			Transport: newTransport(),
+		},
+		headers: http.Header{},
+		token:   func(context.Context) string { return "" },
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+func newTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   5 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   20,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+
+	if token := c.token(ctx); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return req, nil
+}
+
+// doJSON sends in (when not nil) as a JSON body and decodes the response into
+// out. op names the operation in error messages, e.g. "create product".
+func (c *Client) doJSON(ctx context.Context, op, method, path string, in, out any) error {
+	return c.doJSONWithHeader(ctx, op, method, path, nil, in, out)
+}
+
+// doJSONWithHeader is doJSON with extra headers for this request only.
+func (c *Client) doJSONWithHeader(
+	ctx context.Context,
+	op, method, path string,
+	header http.Header,
+	in, out any,
+) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("marshal %s request: %w", op, err)
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := c.newRequest(ctx, method, path, body)
+	if err != nil {
+		return fmt.Errorf("create %s request: %w", op, err)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	return c.do(req, op, out)
+}
+
+// do sends req and decodes the response into out. A 401 on an authenticated
+// request is retried once with a renewed access token when the client has a
+// TokenRefresher.
+func (c *Client) do(req *http.Request, op string, out any) error {
+	err := c.send(req, op, out)
+	if !c.canRefresh(req, err) {
+		return err
+	}
+
+	token, refreshErr := c.renewAccessToken(req.Context())
+	if refreshErr != nil {
+		return fmt.Errorf("%w: %w", ErrSessionExpired, err)
+	}
+
+	retry, retryErr := retryRequest(req, token)
+	if retryErr != nil {
+		return fmt.Errorf("retry %s request: %w", op, retryErr)
+	}
+
+	return c.send(retry, op, out)
+}
+
+func (c *Client) send(req *http.Request, op string, out any) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send %s request: %w: %w", op, ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read %s response: %w", op, err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return newError(op, resp.StatusCode, respBody)
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("decode %s response: %w", op, err)
+	}
+
+	return nil
+}
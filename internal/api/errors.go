@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+)
+
+// Error codes returned by the backend in dtos.ErrorResponse.Code.
+const (
+	CodeEmailTaken   = "email_taken"
+	CodeSKUExists    = "sku_exists"
+	CodeInvalidToken = "invalid_token"
+)
+
+// ErrUnavailable is returned, wrapped together with the transport error, when
+// the backend could not be reached at all.
+var ErrUnavailable = errors.New("backend unavailable")
+
+// Error is a non-2xx response from the backend. Use errors.As to inspect it.
+type Error struct {
+	Op         string
+	StatusCode int
+	Message    string
+	Code       string
+	Fields     map[string]string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s failed (%d): %s", e.Op, e.StatusCode, e.Message)
+}
+
+func (e *Error) IsUnauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *Error) IsForbidden() bool {
+	return e.StatusCode == http.StatusForbidden
+}
+
+func (e *Error) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+func (e *Error) IsConflict() bool {
+	return e.StatusCode == http.StatusConflict ||
+		e.Code == CodeEmailTaken ||
+		e.Code == CodeSKUExists
+}
+
+func (e *Error) IsValidation() bool {
+	return e.StatusCode == http.StatusBadRequest ||
+		e.StatusCode == http.StatusUnprocessableEntity ||
+		len(e.Fields) > 0
+}
+
+func newError(op string, statusCode int, body []byte) *Error {
+	apiErr := &Error{
+		Op:         op,
+		StatusCode: statusCode,
+	}
+
+	var errResp dtos.ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil {
+		apiErr.Message = strings.TrimSpace(string(body))
+	} else {
+		apiErr.Code = errResp.Code
+		apiErr.Fields = errResp.Fields
+		apiErr.Message = errResp.Error
+		if apiErr.Message == "" {
+			apiErr.Message = errResp.Message
+		}
+	}
+
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(statusCode)
+	}
+
+	return apiErr
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+)
+
+// CreateOrder places an order. The backend returns the order created the
+// first time for any repeated request with the same idempotencyKey.
+func (c *Client) CreateOrder(
+	ctx context.Context,
+	order dtos.CreateOrderRequest,
+	idempotencyKey string,
+) (dtos.SingleOrderResponse, error) {
+	header := http.Header{}
+	header.Set("Idempotency-Key", idempotencyKey)
+
+	var orderResp dtos.SingleOrderResponse
+	if err := c.doJSONWithHeader(ctx, "create order", http.MethodPost, "/orders", header, order, &orderResp); err != nil {
+		return dtos.SingleOrderResponse{}, err
+	}
+
+	return orderResp, nil
+}
+
+func (c *Client) GetOrder(ctx context.Context, id int) (dtos.SingleOrderResponse, error) {
+	path := fmt.Sprintf("/orders/%d", id)
+
+	var orderResp dtos.SingleOrderResponse
+	if err := c.doJSON(ctx, "get order", http.MethodGet, path, nil, &orderResp); err != nil {
+		return dtos.SingleOrderResponse{}, err
+	}
+
+	return orderResp, nil
+}
+
+// ListOrders returns a page of the orders of the user the token belongs to.
+func (c *Client) ListOrders(ctx context.Context, page, limit int) (dtos.OrderListResponse, error) {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+
+	var ordersResp dtos.OrderListResponse
+	if err := c.doJSON(ctx, "list orders", http.MethodGet, "/orders?"+query.Encode(), nil, &ordersResp); err != nil {
+		return dtos.OrderListResponse{}, err
+	}
+
+	return ordersResp, nil
+}
+
+// AdminListOrders returns a page of every customer's orders matching filter.
+func (c *Client) AdminListOrders(ctx context.Context, filter dtos.OrderFilter) (dtos.OrderListResponse, error) {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(filter.Page))
+	query.Set("limit", strconv.Itoa(filter.Limit))
+	if filter.Status != "" {
+		query.Set("status", filter.Status)
+	}
+	if filter.From != "" {
+		query.Set("from", filter.From)
+	}
+	if filter.To != "" {
+		query.Set("to", filter.To)
+	}
+
+	var ordersResp dtos.OrderListResponse
+	if err := c.doJSON(ctx, "admin list orders", http.MethodGet, "/admin/orders?"+query.Encode(), nil, &ordersResp); err != nil {
+		return dtos.OrderListResponse{}, err
+	}
+
+	return ordersResp, nil
+}
+
+// AdminGetOrder returns any customer's order, for staff.
+func (c *Client) AdminGetOrder(ctx context.Context, id int) (dtos.SingleOrderResponse, error) {
+	path := fmt.Sprintf("/admin/orders/%d", id)
+
+	var orderResp dtos.SingleOrderResponse
+	if err := c.doJSON(ctx, "admin get order", http.MethodGet, path, nil, &orderResp); err != nil {
+		return dtos.SingleOrderResponse{}, err
+	}
+
+	return orderResp, nil
+}
+
+// UpdateOrderStatus moves an order to a new status. The backend records the
+// change in the order's history.
+func (c *Client) UpdateOrderStatus(
+	ctx context.Context,
+	id int,
+	req dtos.UpdateOrderStatusRequest,
+) (dtos.SingleOrderResponse, error) {
+	path := fmt.Sprintf("/admin/orders/%d/status", id)
+
+	var orderResp dtos.SingleOrderResponse
+	if err := c.doJSON(ctx, "update order status", http.MethodPatch, path, req, &orderResp); err != nil {
+		return dtos.SingleOrderResponse{}, err
+	}
+
+	return orderResp, nil
+}
+
+func (c *Client) GetOrderHistory(ctx context.Context, id int) (dtos.OrderHistoryResponse, error) {
+	path := fmt.Sprintf("/admin/orders/%d/history", id)
+
+	var historyResp dtos.OrderHistoryResponse
+	if err := c.doJSON(ctx, "get order history", http.MethodGet, path, nil, &historyResp); err != nil {
+		return dtos.OrderHistoryResponse{}, err
+	}
+
+	return historyResp, nil
+}
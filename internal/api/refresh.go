@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrSessionExpired is returned, wrapped together with the backend's 401,
+// when an expired access token could not be renewed.
+var ErrSessionExpired = errors.New("session expired")
+
+// TokenRefresher gives the client access to the refresh token of the user a
+// request is made for, so an expired access token can be renewed.
+type TokenRefresher interface {
+	// RefreshToken returns the refresh token for ctx, or "" if there is none.
+	RefreshToken(ctx context.Context) string
+	// TokensRefreshed stores the tokens issued by the backend.
+	TokensRefreshed(ctx context.Context, tokens dtos.TokenPair) error
+	// RefreshFailed is called when the access token could not be renewed.
+	RefreshFailed(ctx context.Context)
+}
+
+// WithTokenRefresher makes the client renew the access token and retry once
+// when the backend rejects an authenticated request with 401.
+func WithTokenRefresher(refresher TokenRefresher) Option {
+	return func(c *Client) {
+		c.refresher = refresher
+	}
+}
+
+type noRefreshKey struct{}
+
+// canRefresh reports whether a request that failed with err should be retried
+// with a renewed access token.
+func (c *Client) canRefresh(req *http.Request, err error) bool {
+	if c.refresher == nil || req.Header.Get("Authorization") == "" {
+		return false
+	}
+	if skip, _ := req.Context().Value(noRefreshKey{}).(bool); skip {
+		return false
+	}
+
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.IsUnauthorized()
+}
+
+// renewalGrace is how long the tokens issued for a refresh token are handed
+// to other requests of the same session that still present it.
+const renewalGrace = time.Minute
+
+// renewals makes the requests of one session share a single refresh. The
+// backend rotates refresh tokens, so a second refresh with the same token
+// would fail and log the user out. Renewals are keyed by the refresh token,
+// which identifies the session whichever store keeps it.
+type renewals struct {
+	group singleflight.Group
+
+	mu     sync.Mutex
+	recent map[string]renewal
+}
+
+type renewal struct {
+	tokens    dtos.TokenPair
+	renewedAt time.Time
+}
+
+// renew returns the tokens issued for refreshToken, calling refresh only if no
+// other request of the session is renewing it or renewed it moments ago.
+func (r *renewals) renew(refreshToken string, refresh func() (dtos.TokenPair, error)) (dtos.TokenPair, error) {
+	v, err, _ := r.group.Do(refreshToken, func() (any, error) {
+		// A request that read the session before another one renewed it
+		// still holds the old refresh token.
+		if tokens, ok := r.lookup(refreshToken); ok {
+			return tokens, nil
+		}
+
+		tokens, err := refresh()
+		if err != nil {
+			return nil, err
+		}
+		r.store(refreshToken, tokens)
+
+		return tokens, nil
+	})
+	if err != nil {
+		return dtos.TokenPair{}, err
+	}
+
+	return v.(dtos.TokenPair), nil
+}
+
+func (r *renewals) lookup(refreshToken string) (dtos.TokenPair, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	renewed, ok := r.recent[refreshToken]
+	if !ok || time.Since(renewed.renewedAt) > renewalGrace {
+		return dtos.TokenPair{}, false
+	}
+
+	return renewed.tokens, true
+}
+
+func (r *renewals) store(refreshToken string, tokens dtos.TokenPair) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for token, renewed := range r.recent {
+		if now.Sub(renewed.renewedAt) > renewalGrace {
+			delete(r.recent, token)
+		}
+	}
+
+	if r.recent == nil {
+		r.recent = map[string]renewal{}
+	}
+	r.recent[refreshToken] = renewal{tokens: tokens, renewedAt: now}
+}
+
+func (c *Client) renewAccessToken(ctx context.Context) (string, error) {
+	refreshToken := c.refresher.RefreshToken(ctx)
+	if refreshToken == "" {
+		c.refresher.RefreshFailed(ctx)
+		return "", errors.New("no refresh token")
+	}
+
+	tokens, err := c.renewals.renew(refreshToken, func() (dtos.TokenPair, error) {
+		// Requests waiting on this refresh must not fail because the one
+		// that started it was canceled.
+		resp, err := c.RefreshToken(context.WithoutCancel(ctx), refreshToken)
+		return resp.Tokens, err
+	})
+	if err != nil {
+		c.refresher.RefreshFailed(ctx)
+		return "", err
+	}
+
+	// Each request stores the tokens in its own copy of the session.
+	if err := c.refresher.TokensRefreshed(ctx, tokens); err != nil {
+		return "", fmt.Errorf("store refreshed tokens: %w", err)
+	}
+
+	return tokens.AccessToken, nil
+}
+
+// retryRequest returns a copy of req, with a fresh body, authenticated with
+// token.
+func retryRequest(req *http.Request, token string) (*http.Request, error) {
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	retry.Header.Set("Authorization", "Bearer "+token)
+
+	return retry, nil
+}
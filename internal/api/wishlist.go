@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+)
+
+func (c *Client) GetWishlist(ctx context.Context) (dtos.WishlistResponse, error) {
+	var wishlistResp dtos.WishlistResponse
+	if err := c.doJSON(ctx, "get wishlist", http.MethodGet, "/wishlist", nil, &wishlistResp); err != nil {
+		return dtos.WishlistResponse{}, err
+	}
+
+	return wishlistResp, nil
+}
+
+func (c *Client) AddWishlistItem(ctx context.Context, productID int) (dtos.WishlistResponse, error) {
+	req := dtos.AddWishlistItemRequest{ProductID: productID}
+
+	var wishlistResp dtos.WishlistResponse
+	if err := c.doJSON(ctx, "add wishlist item", http.MethodPost, "/wishlist/items", req, &wishlistResp); err != nil {
+		return dtos.WishlistResponse{}, err
+	}
+
+	return wishlistResp, nil
+}
+
+func (c *Client) RemoveWishlistItem(ctx context.Context, productID int) (dtos.WishlistResponse, error) {
+	path := fmt.Sprintf("/wishlist/items/%d", productID)
+
+	var wishlistResp dtos.WishlistResponse
+	if err := c.doJSON(ctx, "remove wishlist item", http.MethodDelete, path, nil, &wishlistResp); err != nil {
+		return dtos.WishlistResponse{}, err
+	}
+
+	return wishlistResp, nil
+}
+
+// MergeWishlist adds the items picked as a guest to the user's wishlist and
+// returns the result.
+func (c *Client) MergeWishlist(ctx context.Context, items []dtos.WishlistItem) (dtos.WishlistResponse, error) {
+	req := dtos.MergeWishlistRequest{Items: items}
+
+	var wishlistResp dtos.WishlistResponse
+	if err := c.doJSON(ctx, "merge wishlist", http.MethodPost, "/wishlist/merge", req, &wishlistResp); err != nil {
+		return dtos.WishlistResponse{}, err
+	}
+
+	return wishlistResp, nil
+}
@@ -0,0 +1,107 @@
+// Package authz decides what staff users may do in the admin dashboard.
+// Roles come from the backend; each role grants a set of permissions.
+package authz
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Permission names an action as "resource:action".
+type Permission string
+
+const (
+	ProductsRead    Permission = "products:read"
+	ProductsWrite   Permission = "products:write"
+	CategoriesRead  Permission = "categories:read"
+	CategoriesWrite Permission = "categories:write"
+	OrdersRead      Permission = "orders:read"
+	OrdersWrite     Permission = "orders:write"
+)
+
+// All lists every permission, so wildcards can be expanded.
+var All = []Permission{
+	ProductsRead,
+	ProductsWrite,
+	CategoriesRead,
+	CategoriesWrite,
+	OrdersRead,
+	OrdersWrite,
+}
+
+// Staff roles.
+const (
+	RoleOwner          = "owner"
+	RoleAdmin          = "admin"
+	RoleCatalogEditor  = "catalog_editor"
+	RoleOrderFulfiller = "order_fulfiller"
+	RoleSupport        = "support"
+)
+
+// DefaultRoles is the role to permission map used unless the configuration
+// overrides a role. A grant is a permission, "resource:*" or "*".
+func DefaultRoles() map[string][]string {
+	return map[string][]string{
+		RoleOwner:          {"*"},
+		RoleAdmin:          {"*"},
+		RoleCatalogEditor:  {"products:*", "categories:*"},
+		RoleOrderFulfiller: {"orders:*", "products:read"},
+		RoleSupport:        {"orders:read", "products:read", "categories:read"},
+	}
+}
+
+// Policy maps each role to the permissions it grants.
+type Policy map[string][]Permission
+
+// NewPolicy expands the grants of every role into permissions. An unknown
+// permission or resource is an error, so a typo cannot silently grant
+// nothing.
+func NewPolicy(roles map[string][]string) (Policy, error) {
+	policy := make(Policy, len(roles))
+	for role, grants := range roles {
+		var perms []Permission
+		for _, grant := range grants {
+			expanded := expand(grant)
+			if len(expanded) == 0 {
+				return nil, fmt.Errorf("role %s: unknown permission %q", role, grant)
+			}
+			for _, perm := range expanded {
+				if !slices.Contains(perms, perm) {
+					perms = append(perms, perm)
+				}
+			}
+		}
+		policy[role] = perms
+	}
+
+	return policy, nil
+}
+
+// Permissions returns what role may do. Unknown roles, customers among them,
+// get nothing.
+func (p Policy) Permissions(role string) []Permission {
+	return p[role]
+}
+
+func expand(grant string) []Permission {
+	if grant == "*" {
+		return All
+	}
+
+	var perms []Permission
+	if resource, ok := strings.CutSuffix(grant, ":*"); ok {
+		for _, perm := range All {
+			if strings.HasPrefix(string(perm), resource+":") {
+				perms = append(perms, perm)
+			}
+		}
+		return perms
+	}
+
+	if slices.Contains(All, Permission(grant)) {
+		perms = append(perms, Permission(grant))
+	}
+
+	return perms
+}
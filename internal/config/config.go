@@ -0,0 +1,576 @@
+// Package config loads the settings of the web app from the environment.
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/gommon/bytes"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/authz"
+)
+
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+)
+
+// Where sessions are kept.
+const (
+	SessionStoreCookie   = "cookie"
+	SessionStorePostgres = "postgres"
+	SessionStoreMemory   = "memory"
+)
+
+// Development defaults of the secrets. Production refuses to start with them.
+const (
+	defaultSessionKey = "zRJdixjhVNDh..."
+	defaultCSRFKey    = "32-byte-secret-key-minimo-32-chars!!"
+)
+
+// minSecretLen is the shortest secret, in bytes, accepted in production.
+const minSecretLen = 32
+
+type Config struct {
+	// Env is EnvDevelopment or EnvProduction.
+	Env    string
+	Server Server
+	API    API
+	// AppURL is the public URL of the site, used for links in emails.
+	AppURL   string
+	Mail     Mail
+	Database Database
+	Session  Session
+	CSRF     CSRF
+	Upload   Upload
+	// Roles grants permissions in the admin dashboard to each staff role.
+	Roles authz.Policy
+
+	// defaultSecrets lists the secrets left at their development default.
+	defaultSecrets []string
+}
+
+type Server struct {
+	Host            string
+	Port            int
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+}
+
+type API struct {
+	// URL is the backend base URL. It always ends in a slash.
+	URL     string
+	Timeout time.Duration
+	// ServiceKey authenticates the web app itself to the backend for calls
+	// no user is logged in for, such as issuing password-reset tokens.
+	ServiceKey string
+}
+
+type Mail struct {
+	OutboxDir string
+	From      string
+}
+
+type Database struct {
+	// URL is the PostgreSQL connection string, required by the postgres
+	// session store.
+	URL string
+}
+
+type Session struct {
+	// Store is SessionStoreCookie, SessionStorePostgres or
+	// SessionStoreMemory.
+	Store string
+	// CleanupInterval is how often expired sessions are deleted from a
+	// server-side store.
+	CleanupInterval time.Duration
+	// IdleTimeout logs out a session that was not used for that long.
+	// Remembered sessions are exempt.
+	IdleTimeout time.Duration
+	// MaxLifetime and RememberLifetime bound how long a login lasts, however
+	// active it is, without and with "remember me".
+	MaxLifetime      time.Duration
+	RememberLifetime time.Duration
+	Cookie           Cookie
+	AuthKey          []byte
+	EncKey           []byte
+	// Previous holds retired key pairs, newest first. Cookies signed with
+	// them are still accepted until they expire.
+	Previous []KeyPair
+}
+
+// Cookie holds the flags of the session cookies.
+type Cookie struct {
+	Secure   bool
+	SameSite http.SameSite
+	Domain   string
+}
+
+type KeyPair struct {
+	AuthKey []byte
+	EncKey  []byte
+}
+
+// KeyPairs returns the session keys in the form sessions.NewCookieStore
+// takes them: the current pair first, which signs new cookies, followed by
+// the previous pairs, which are only used to read old ones.
+func (s Session) KeyPairs() [][]byte {
+	pairs := [][]byte{s.AuthKey, s.EncKey}
+	for _, p := range s.Previous {
+		pairs = append(pairs, p.AuthKey, p.EncKey)
+	}
+
+	return pairs
+}
+
+type CSRF struct {
+	Key []byte
+	// PreviousKeys are retired CSRF keys, newest first. A token cookie signed
+	// with one of them is re-signed with Key instead of being rejected.
+	PreviousKeys   [][]byte
+	CookieSecure   bool
+	TrustedOrigins []string
+}
+
+type Upload struct {
+	// MaxSize is the largest request body, in bytes, accepted by the
+	// product forms.
+	MaxSize   int64
+	MaxImages int
+}
+
+// Load reads the configuration from the environment and validates it. Every
+// malformed setting is reported at once.
+func Load() (Config, error) {
+	var l loader
+
+	secrets := map[string]string{
+		"SESSION_AUTH_KEY": l.string("SESSION_AUTH_KEY", defaultSessionKey),
+		"SESSION_ENC_KEY":  l.string("SESSION_ENC_KEY", defaultSessionKey),
+		"CSRF_TOKEN_KEY":   l.string("CSRF_TOKEN_KEY", defaultCSRFKey),
+	}
+
+	var previousSessions []KeyPair
+	for i, pair := range l.pairs("SESSION_PREVIOUS_KEYS") {
+		secrets[fmt.Sprintf("SESSION_PREVIOUS_KEYS[%d].auth", i)] = pair[0]
+		secrets[fmt.Sprintf("SESSION_PREVIOUS_KEYS[%d].enc", i)] = pair[1]
+		previousSessions = append(previousSessions, KeyPair{
+			AuthKey: sessionKey(pair[0]),
+			EncKey:  sessionKey(pair[1]),
+		})
+	}
+
+	var previousCSRF [][]byte
+	for i, key := range l.list("CSRF_PREVIOUS_KEYS") {
+		secrets[fmt.Sprintf("CSRF_PREVIOUS_KEYS[%d]", i)] = key
+		previousCSRF = append(previousCSRF, secretBytes(key))
+	}
+
+	csrfSecure := l.bool("CSRF_COOKIE_SECURE", false)
+
+	cfg := Config{
+		Env: l.string("APP_ENV", EnvDevelopment),
+		Server: Server{
+			Host:            l.string("SERVER_HOST", "0.0.0.0"),
+			Port:            l.int("SERVER_PORT", 9090),
+			ReadTimeout:     l.duration("SERVER_READ_TIMEOUT", 30*time.Second),
+			WriteTimeout:    l.duration("SERVER_WRITE_TIMEOUT", 30*time.Second),
+			IdleTimeout:     l.duration("SERVER_IDLE_TIMEOUT", 2*time.Minute),
+			ShutdownTimeout: l.duration("SERVER_SHUTDOWN_TIMEOUT", 15*time.Second),
+		},
+		API: API{
+			URL:        l.url("API_URL", "http://localhost:8080/api/v1/"),
+			Timeout:    time.Duration(l.int("API_TIMEOUT_SECONDS", 10)) * time.Second,
+			ServiceKey: l.string("API_SERVICE_KEY", ""),
+		},
+		AppURL: strings.TrimSuffix(l.url("APP_URL", "http://localhost:9090"), "/"),
+		Mail: Mail{
+			OutboxDir: l.string("MAIL_OUTBOX_DIR", "tmp/outbox"),
+			From:      l.string("MAIL_FROM", "Alejandrinas <no-reply@alejandrina.shop>"),
+		},
+		Database: Database{
+			URL: l.string("DB_ADDR", ""),
+		},
+		Session: Session{
+			Store:            l.string("SESSION_STORE", SessionStoreCookie),
+			CleanupInterval:  l.duration("SESSION_CLEANUP_INTERVAL", 15*time.Minute),
+			IdleTimeout:      l.duration("SESSION_IDLE_TIMEOUT", 2*time.Hour),
+			MaxLifetime:      l.duration("SESSION_MAX_LIFETIME", 24*time.Hour),
+			RememberLifetime: l.duration("SESSION_REMEMBER_LIFETIME", 30*24*time.Hour),
+			Cookie: Cookie{
+				// The CSRF cookie and the session cookies travel together,
+				// so they share the default.
+				Secure:   l.bool("SESSION_COOKIE_SECURE", csrfSecure),
+				SameSite: l.sameSite("SESSION_COOKIE_SAMESITE", http.SameSiteLaxMode),
+				Domain:   l.string("SESSION_COOKIE_DOMAIN", ""),
+			},
+			AuthKey:  sessionKey(secrets["SESSION_AUTH_KEY"]),
+			EncKey:   sessionKey(secrets["SESSION_ENC_KEY"]),
+			Previous: previousSessions,
+		},
+		CSRF: CSRF{
+			Key:            secretBytes(secrets["CSRF_TOKEN_KEY"]),
+			PreviousKeys:   previousCSRF,
+			CookieSecure:   csrfSecure,
+			TrustedOrigins: l.list("CSRF_TRUSTED_ORIGINS"),
+		},
+		Upload: Upload{
+			MaxSize:   l.bytes("UPLOAD_MAX_SIZE", "20MiB"),
+			MaxImages: l.int("UPLOAD_MAX_IMAGES", 8),
+		},
+		Roles: l.roles("ROLE_PERMISSIONS", authz.DefaultRoles()),
+	}
+
+	if err := errors.Join(l.errs...); err != nil {
+		return Config{}, err
+	}
+	for _, key := range slices.Sorted(maps.Keys(secrets)) {
+		if secrets[key] == defaultSessionKey || secrets[key] == defaultCSRFKey {
+			cfg.defaultSecrets = append(cfg.defaultSecrets, key)
+		}
+	}
+
+	if err := cfg.validate(); err != nil {
+		return Config{}, err
+	}
+	if cfg.IsProduction() {
+		if err := validateSecrets(secrets); err != nil {
+			return Config{}, err
+		}
+	}
+
+	cfg.API.URL = strings.TrimSuffix(cfg.API.URL, "/") + "/"
+
+	return cfg, nil
+}
+
+func (cfg Config) validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(cfg.Env == EnvDevelopment || cfg.Env == EnvProduction, "APP_ENV: must be %q or %q", EnvDevelopment, EnvProduction)
+	check(cfg.Server.Port > 0 && cfg.Server.Port <= 65535, "SERVER_PORT: %d is not a valid port", cfg.Server.Port)
+	check(cfg.Server.ReadTimeout > 0, "SERVER_READ_TIMEOUT: must be positive")
+	check(cfg.Server.WriteTimeout > 0, "SERVER_WRITE_TIMEOUT: must be positive")
+	check(cfg.Server.IdleTimeout > 0, "SERVER_IDLE_TIMEOUT: must be positive")
+	check(cfg.Server.ShutdownTimeout > 0, "SERVER_SHUTDOWN_TIMEOUT: must be positive")
+	check(cfg.API.Timeout > 0, "API_TIMEOUT_SECONDS: must be positive")
+	check(!cfg.IsProduction() || cfg.API.ServiceKey != "", "API_SERVICE_KEY: must be set in production")
+	check(strings.TrimSpace(cfg.Mail.OutboxDir) != "", "MAIL_OUTBOX_DIR: must not be empty")
+	check(strings.TrimSpace(cfg.Mail.From) != "", "MAIL_FROM: must not be empty")
+	check(slices.Contains([]string{SessionStoreCookie, SessionStorePostgres, SessionStoreMemory}, cfg.Session.Store),
+		"SESSION_STORE: must be %q, %q or %q", SessionStoreCookie, SessionStorePostgres, SessionStoreMemory)
+	check(cfg.Session.Store != SessionStorePostgres || cfg.Database.URL != "", "DB_ADDR: required by SESSION_STORE=%s", SessionStorePostgres)
+	check(cfg.Session.CleanupInterval > 0, "SESSION_CLEANUP_INTERVAL: must be positive")
+	check(cfg.Session.IdleTimeout > 0, "SESSION_IDLE_TIMEOUT: must be positive")
+	check(cfg.Session.MaxLifetime > 0, "SESSION_MAX_LIFETIME: must be positive")
+	check(cfg.Session.RememberLifetime >= cfg.Session.MaxLifetime, "SESSION_REMEMBER_LIFETIME: must be at least SESSION_MAX_LIFETIME")
+	check(cfg.Session.Cookie.SameSite != http.SameSiteNoneMode || cfg.Session.Cookie.Secure,
+		"SESSION_COOKIE_SAMESITE: none requires SESSION_COOKIE_SECURE=true")
+	// securecookie encrypts with AES, which only takes 16, 24 or 32-byte keys.
+	aesKey := func(key []byte) bool { return slices.Contains([]int{16, 24, 32}, len(key)) }
+	check(aesKey(cfg.Session.EncKey), "SESSION_ENC_KEY: must be 16, 24 or 32 bytes, got %d", len(cfg.Session.EncKey))
+	for i, pair := range cfg.Session.Previous {
+		check(aesKey(pair.EncKey), "SESSION_PREVIOUS_KEYS[%d]: encryption key must be 16, 24 or 32 bytes, got %d", i, len(pair.EncKey))
+	}
+	check(len(cfg.CSRF.Key) >= 32, "CSRF_TOKEN_KEY: must be at least 32 bytes")
+	for i, key := range cfg.CSRF.PreviousKeys {
+		check(len(key) >= 32, "CSRF_PREVIOUS_KEYS[%d]: must be at least 32 bytes", i)
+	}
+	check(cfg.Upload.MaxSize > 0, "UPLOAD_MAX_SIZE: must be positive")
+	check(cfg.Upload.MaxImages > 0, "UPLOAD_MAX_IMAGES: must be positive")
+
+	return errors.Join(errs...)
+}
+
+// validateSecrets checks the secrets production depends on: each must be set,
+// differ from the development default and from the others, and be at least
+// minSecretLen bytes long, measured as secretBytes uses it.
+func validateSecrets(secrets map[string]string) error {
+	var errs []error
+	seen := map[string]string{}
+	for _, key := range slices.Sorted(maps.Keys(secrets)) {
+		raw := secrets[key]
+		switch {
+		case raw == defaultSessionKey || raw == defaultCSRFKey:
+			errs = append(errs, fmt.Errorf("%s: must be set in production", key))
+		case secretLen(raw) < minSecretLen:
+			errs = append(errs, fmt.Errorf("%s: must be at least %d bytes in production", key, minSecretLen))
+		case seen[raw] != "":
+			errs = append(errs, fmt.Errorf("%s: must differ from %s", key, seen[raw]))
+		}
+		seen[raw] = key
+	}
+
+	return errors.Join(errs...)
+}
+
+// secretLen returns the length in bytes of the key a secret stands for.
+func secretLen(raw string) int {
+	return len(secretBytes(raw))
+}
+
+// secretBytes returns the key a secret stands for: its base64 decoding when
+// that is at least minSecretLen bytes, like the output of keygen or
+// openssl rand -base64 32, and the secret itself otherwise.
+func secretBytes(raw string) []byte {
+	decoded, err := base64.StdEncoding.DecodeString(raw)
+	if err == nil && len(decoded) >= minSecretLen {
+		return decoded
+	}
+
+	return []byte(raw)
+}
+
+func (cfg Config) IsProduction() bool {
+	return cfg.Env == EnvProduction
+}
+
+// LogSecurity reports which security settings are active. Secrets are
+// never logged, only whether they were configured.
+func (cfg Config) LogSecurity(logger *slog.Logger) {
+	logger.Info("security settings",
+		"env", cfg.Env,
+		"session_store", cfg.Session.Store,
+		"app_url", cfg.AppURL,
+		"session_cookie_secure", cfg.Session.Cookie.Secure,
+		"session_idle_timeout", cfg.Session.IdleTimeout,
+		"csrf_cookie_secure", cfg.CSRF.CookieSecure,
+		"csrf_trusted_origins", cfg.CSRF.TrustedOrigins,
+		"session_previous_keys", len(cfg.Session.Previous),
+		"csrf_previous_keys", len(cfg.CSRF.PreviousKeys),
+		"api_service_key", cfg.API.ServiceKey != "",
+		"default_secrets", cfg.defaultSecrets,
+	)
+
+	for _, key := range cfg.defaultSecrets {
+		logger.Warn("using the development default secret; set it before deploying", "setting", key)
+	}
+	if !cfg.CSRF.CookieSecure {
+		logger.Warn("cookies are sent over plain HTTP; set CSRF_COOKIE_SECURE=true behind HTTPS")
+	}
+	if !cfg.Session.Cookie.Secure && cfg.CSRF.CookieSecure {
+		logger.Warn("session cookies are sent over plain HTTP; set SESSION_COOKIE_SECURE=true behind HTTPS")
+	}
+	if cfg.IsProduction() && cfg.Session.Store == SessionStoreMemory {
+		logger.Warn("sessions are kept in memory; they are lost on restart and not shared between instances")
+	}
+	if cfg.IsProduction() && !strings.HasPrefix(cfg.AppURL, "https://") {
+		logger.Warn("APP_URL is not HTTPS; links in emails will not be secure", "app_url", cfg.AppURL)
+	}
+}
+
+// sessionKey decodes a base64 key of at least 32 bytes, or derives a 32-byte
+// key from any other string.
+func sessionKey(raw string) []byte {
+	decoded, err := base64.StdEncoding.DecodeString(raw)
+	if err == nil && len(decoded) >= 32 {
+		return decoded
+	}
+
+	// Normalize arbitrary strings into a 32-byte key using SHA256.
+	hash := sha256.Sum256([]byte(raw))
+	return hash[:]
+}
+
+// loader reads environment variables. A malformed value is collected as an
+// error instead of silently replaced by the default.
+type loader struct {
+	errs []error
+}
+
+func (l *loader) lookup(key string) (string, bool) {
+	val, ok := os.LookupEnv(key)
+	if !ok || strings.TrimSpace(val) == "" {
+		return "", false
+	}
+
+	return strings.TrimSpace(val), true
+}
+
+func (l *loader) fail(key string, val string, err error) {
+	l.errs = append(l.errs, fmt.Errorf("%s: invalid value %q: %w", key, val, err))
+}
+
+func (l *loader) string(key, fallback string) string {
+	if val, ok := l.lookup(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+func (l *loader) int(key string, fallback int) int {
+	val, ok := l.lookup(key)
+	if !ok {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(val)
+	if err != nil {
+		l.fail(key, val, err)
+	}
+
+	return n
+}
+
+func (l *loader) bool(key string, fallback bool) bool {
+	val, ok := l.lookup(key)
+	if !ok {
+		return fallback
+	}
+
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		l.fail(key, val, err)
+	}
+
+	return b
+}
+
+func (l *loader) duration(key string, fallback time.Duration) time.Duration {
+	val, ok := l.lookup(key)
+	if !ok {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		l.fail(key, val, err)
+	}
+
+	return d
+}
+
+// bytes reads a size such as "20MiB" or "512KiB".
+func (l *loader) bytes(key, fallback string) int64 {
+	val, ok := l.lookup(key)
+	if !ok {
+		val = fallback
+	}
+
+	n, err := bytes.Parse(val)
+	if err != nil {
+		l.fail(key, val, err)
+	}
+
+	return n
+}
+
+// pairs reads a comma-separated list of "auth:enc" key pairs.
+func (l *loader) pairs(key string) [][2]string {
+	var pairs [][2]string
+	for i, item := range l.list(key) {
+		auth, enc, ok := strings.Cut(item, ":")
+		auth, enc = strings.TrimSpace(auth), strings.TrimSpace(enc)
+		if !ok || auth == "" || enc == "" {
+			// The value is a secret, so it is left out of the error.
+			l.errs = append(l.errs, fmt.Errorf(`%s[%d]: must be "auth:enc"`, key, i))
+			continue
+		}
+		pairs = append(pairs, [2]string{auth, enc})
+	}
+
+	return pairs
+}
+
+// sameSite reads a SameSite cookie mode: lax, strict or none.
+func (l *loader) sameSite(key string, fallback http.SameSite) http.SameSite {
+	val, ok := l.lookup(key)
+	if !ok {
+		return fallback
+	}
+
+	switch strings.ToLower(val) {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	}
+	l.fail(key, val, errors.New(`must be "lax", "strict" or "none"`))
+
+	return fallback
+}
+
+// roles reads role grants as "role=grant,grant;role=grant" on top of
+// defaults. A listed role replaces its default grants; "role=" removes them.
+func (l *loader) roles(key string, defaults map[string][]string) authz.Policy {
+	roles := defaults
+	if val, ok := l.lookup(key); ok {
+		for entry := range strings.SplitSeq(val, ";") {
+			if strings.TrimSpace(entry) == "" {
+				continue
+			}
+			role, grants, ok := strings.Cut(entry, "=")
+			role = strings.TrimSpace(role)
+			if !ok || role == "" {
+				l.fail(key, entry, errors.New(`must be "role=grant,grant"`))
+				continue
+			}
+
+			roles[role] = nil
+			for grant := range strings.SplitSeq(grants, ",") {
+				if grant = strings.TrimSpace(grant); grant != "" {
+					roles[role] = append(roles[role], grant)
+				}
+			}
+		}
+	}
+
+	policy, err := authz.NewPolicy(roles)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: %w", key, err))
+	}
+
+	return policy
+}
+
+// url reads an absolute http or https URL.
+func (l *loader) url(key, fallback string) string {
+	val, ok := l.lookup(key)
+	if !ok {
+		val = fallback
+	}
+
+	u, err := url.Parse(val)
+	if err == nil && (u.Scheme != "http" && u.Scheme != "https" || u.Host == "") {
+		err = errors.New("must be an absolute http(s) URL")
+	}
+	if err != nil {
+		l.fail(key, val, err)
+	}
+
+	return val
+}
+
+// list reads a comma-separated list, skipping empty items.
+func (l *loader) list(key string) []string {
+	val, _ := l.lookup(key)
+
+	var items []string
+	for item := range strings.SplitSeq(val, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,191 @@
+package config
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+// key returns a base64 secret decoding to n bytes of b.
+func key(b byte, n int) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, n))
+}
+
+func TestValidateSecrets(t *testing.T) {
+	tests := []struct {
+		name    string
+		secrets map[string]string
+		wantErr string
+	}{
+		{
+			name: "distinct base64 keys",
+			secrets: map[string]string{
+				"SESSION_AUTH_KEY": key('a', 32),
+				"SESSION_ENC_KEY":  key('b', 32),
+				"CSRF_TOKEN_KEY":   key('c', 32),
+			},
+		},
+		{
+			name: "long passphrases",
+			secrets: map[string]string{
+				"SESSION_AUTH_KEY": strings.Repeat("auth-", 7),
+				"CSRF_TOKEN_KEY":   strings.Repeat("csrf-", 7),
+			},
+		},
+		{
+			name:    "development default",
+			secrets: map[string]string{"SESSION_AUTH_KEY": defaultSessionKey},
+			wantErr: "SESSION_AUTH_KEY: must be set in production",
+		},
+		{
+			name:    "default CSRF key",
+			secrets: map[string]string{"CSRF_TOKEN_KEY": defaultCSRFKey},
+			wantErr: "CSRF_TOKEN_KEY: must be set in production",
+		},
+		{
+			name:    "short passphrase",
+			secrets: map[string]string{"CSRF_TOKEN_KEY": "not-long-enough"},
+			wantErr: "CSRF_TOKEN_KEY: must be at least 32 bytes",
+		},
+		{
+			// 16 random bytes in base64 are 24 characters: too short either
+			// way.
+			name:    "short base64 key",
+			secrets: map[string]string{"SESSION_ENC_KEY": key('a', 16)},
+			wantErr: "SESSION_ENC_KEY: must be at least 32 bytes",
+		},
+		{
+			name: "reused secret",
+			secrets: map[string]string{
+				"SESSION_AUTH_KEY": key('a', 32),
+				"SESSION_ENC_KEY":  key('a', 32),
+			},
+			wantErr: "SESSION_ENC_KEY: must differ from SESSION_AUTH_KEY",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSecrets(tt.secrets)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateSecrets() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateSecrets() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	production := map[string]string{
+		"APP_ENV":          EnvProduction,
+		"SESSION_AUTH_KEY": key('a', 32),
+		"SESSION_ENC_KEY":  key('b', 32),
+		"CSRF_TOKEN_KEY":   key('c', 32),
+		"API_SERVICE_KEY":  "service-key",
+	}
+	with := func(env map[string]string, key, value string) map[string]string {
+		out := map[string]string{key: value}
+		for k, v := range env {
+			if k != key {
+				out[k] = v
+			}
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{name: "development defaults", env: map[string]string{}},
+		{name: "production", env: production},
+		{
+			name: "previous session keys",
+			env:  with(production, "SESSION_PREVIOUS_KEYS", key('d', 32)+":"+key('e', 32)),
+		},
+		{
+			name:    "unknown environment",
+			env:     map[string]string{"APP_ENV": "staging"},
+			wantErr: "APP_ENV",
+		},
+		{
+			name:    "malformed port",
+			env:     map[string]string{"SERVER_PORT": "http"},
+			wantErr: "SERVER_PORT",
+		},
+		{
+			name:    "port out of range",
+			env:     map[string]string{"SERVER_PORT": "70000"},
+			wantErr: "SERVER_PORT",
+		},
+		{
+			name:    "postgres sessions without a database",
+			env:     map[string]string{"SESSION_STORE": SessionStorePostgres},
+			wantErr: "DB_ADDR",
+		},
+		{
+			name:    "SameSite none without secure cookies",
+			env:     map[string]string{"SESSION_COOKIE_SAMESITE": "none"},
+			wantErr: "SESSION_COOKIE_SAMESITE",
+		},
+		{
+			name:    "encryption key AES cannot use",
+			env:     map[string]string{"SESSION_ENC_KEY": key('b', 48)},
+			wantErr: "SESSION_ENC_KEY: must be 16, 24 or 32 bytes",
+		},
+		{
+			name:    "previous encryption key AES cannot use",
+			env:     map[string]string{"SESSION_PREVIOUS_KEYS": key('d', 32) + ":" + key('e', 64)},
+			wantErr: "SESSION_PREVIOUS_KEYS[0]",
+		},
+		{
+			name:    "malformed previous session keys",
+			env:     map[string]string{"SESSION_PREVIOUS_KEYS": key('d', 32)},
+			wantErr: "SESSION_PREVIOUS_KEYS[0]",
+		},
+		{
+			name:    "production with default secrets",
+			env:     map[string]string{"APP_ENV": EnvProduction, "API_SERVICE_KEY": "service-key"},
+			wantErr: "must be set in production",
+		},
+		{
+			name:    "production without a service key",
+			env:     with(production, "API_SERVICE_KEY", ""),
+			wantErr: "API_SERVICE_KEY",
+		},
+		{
+			name:    "production with a weak CSRF key",
+			env:     with(production, "CSRF_TOKEN_KEY", "short"),
+			wantErr: "CSRF_TOKEN_KEY",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			cfg, err := Load()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Load() = %v, want nil", err)
+				}
+				if !strings.HasSuffix(cfg.API.URL, "/") {
+					t.Errorf("API.URL = %q, want a trailing slash", cfg.API.URL)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Load() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
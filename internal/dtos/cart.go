@@ -0,0 +1,108 @@
+package dtos
+
+type CartItem struct {
+	ProductID int     `json:"product_id"`
+	SKU       string  `json:"sku"`
+	Quantity  int     `json:"quantity"`
+	UnitPrice float64 `json:"unit_price"`
+}
+
+type Cart struct {
+	Items []CartItem `json:"items"`
+}
+
+type CartResponse struct {
+	SharedResponse
+	Cart Cart `json:"data"`
+}
+
+type AddCartItemForm struct {
+	SKU      string `form:"sku"`
+	Quantity int    `form:"quantity"`
+}
+
+type UpdateCartItemForm struct {
+	Quantity int `form:"quantity"`
+}
+
+type UpdateCartItemRequest struct {
+	Quantity int `json:"quantity"`
+}
+
+type MergeCartRequest struct {
+	Items []CartItem `json:"items"`
+}
+
+// CartLine is a cart item checked against the current catalog, ready to be
+// rendered.
+type CartLine struct {
+	CartItem
+	Name          string
+	ImageURL      string
+	Stock         int
+	Available     bool
+	PriceChanged  bool
+	PreviousPrice float64
+	// Unverified is set when the product could not be looked up, for
+	// example while the backend is down.
+	Unverified bool
+	// Discontinued is set when the product was deleted or deactivated.
+	Discontinued bool
+}
+
+func (l CartLine) Subtotal() float64 {
+	return l.UnitPrice * float64(l.Quantity)
+}
+
+// Count returns the number of units in the cart.
+func (c Cart) Count() int {
+	count := 0
+	for _, item := range c.Items {
+		count += item.Quantity
+	}
+
+	return count
+}
+
+// Find returns the index of the item with the given SKU, or -1.
+func (c Cart) Find(sku string) int {
+	for i, item := range c.Items {
+		if item.SKU == sku {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Add appends item, or adds its quantity to an existing line for the same SKU.
+func (c *Cart) Add(item CartItem) {
+	if i := c.Find(item.SKU); i >= 0 {
+		c.Items[i].Quantity += item.Quantity
+		c.Items[i].UnitPrice = item.UnitPrice
+		return
+	}
+
+	c.Items = append(c.Items, item)
+}
+
+// SetQuantity updates the quantity of a line, removing it when quantity is
+// not positive. It reports whether the SKU was in the cart.
+func (c *Cart) SetQuantity(sku string, quantity int) bool {
+	i := c.Find(sku)
+	if i < 0 {
+		return false
+	}
+
+	if quantity <= 0 {
+		c.Items = append(c.Items[:i], c.Items[i+1:]...)
+		return true
+	}
+
+	c.Items[i].Quantity = quantity
+	return true
+}
+
+func (c *Cart) Remove(sku string) bool {
+	return c.SetQuantity(sku, 0)
+}
@@ -1,5 +1,13 @@
 package dtos
 
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gosimple/slug"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/validator"
+)
+
 type CategoryResponse struct {
 	SharedResponse
 	Categories []Category `json:"data"`
@@ -20,9 +28,27 @@ type CreateCategoryForm struct {
 	Description string `form:"category_description"`
 }
 
+func (f CreateCategoryForm) Validate(v *validator.Validator) {
+	v.Check(strings.TrimSpace(f.Name) != "", "category_name", "El nombre es obligatorio")
+	v.Check(utf8.RuneCountInString(f.Name) <= 100, "category_name", "El nombre no puede tener más de 100 caracteres")
+	v.Check(utf8.RuneCountInString(f.Description) <= 500, "category_description", "La descripción no puede tener más de 500 caracteres")
+}
+
 type Category struct {
 	ID          int    `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	IsActive    bool   `json:"is_active"`
 }
+
+// Slug returns the URL path segment of the category's landing page.
+func (c Category) Slug() string {
+	return slug.Make(c.Name)
+}
+
+type CategoryPageForm struct {
+	Sort     string  `query:"sort"`
+	MinPrice float64 `query:"min_price"`
+	MaxPrice float64 `query:"max_price"`
+	Page     int     `query:"page"`
+}
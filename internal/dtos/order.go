@@ -0,0 +1,124 @@
+package dtos
+
+import (
+	"strings"
+	"time"
+
+	"github.com/tikimcrzx723/alejandrinasweb/internal/validator"
+)
+
+type Address struct {
+	FullName   string `json:"full_name"`
+	Street     string `json:"street"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	PostalCode string `json:"postal_code"`
+	Phone      string `json:"phone"`
+}
+
+type AddressForm struct {
+	FullName   string `form:"full_name"`
+	Street     string `form:"street"`
+	City       string `form:"city"`
+	State      string `form:"state"`
+	PostalCode string `form:"postal_code"`
+	Phone      string `form:"phone"`
+}
+
+func (f AddressForm) Validate(v *validator.Validator) {
+	v.Check(strings.TrimSpace(f.FullName) != "", "full_name", "El nombre es obligatorio")
+	v.Check(strings.TrimSpace(f.Street) != "", "street", "La dirección es obligatoria")
+	v.Check(strings.TrimSpace(f.City) != "", "city", "La ciudad es obligatoria")
+	v.Check(strings.TrimSpace(f.State) != "", "state", "El departamento es obligatorio")
+	v.Check(strings.TrimSpace(f.PostalCode) != "", "postal_code", "El código postal es obligatorio")
+	v.Check(validator.Matches(f.Phone, validator.PhoneRX), "phone", "El teléfono no es válido")
+}
+
+func (f AddressForm) Address() Address {
+	return Address{
+		FullName:   strings.TrimSpace(f.FullName),
+		Street:     strings.TrimSpace(f.Street),
+		City:       strings.TrimSpace(f.City),
+		State:      strings.TrimSpace(f.State),
+		PostalCode: strings.TrimSpace(f.PostalCode),
+		Phone:      strings.TrimSpace(f.Phone),
+	}
+}
+
+type ShippingMethod struct {
+	Code  string
+	Name  string
+	Days  string
+	Price float64
+}
+
+type ShippingForm struct {
+	Method string `form:"shipping_method"`
+}
+
+type ConfirmOrderForm struct {
+	IdempotencyKey string `form:"idempotency_key"`
+}
+
+// CheckoutState is what the checkout steps remember between requests.
+type CheckoutState struct {
+	Address        Address
+	ShippingMethod string
+	IdempotencyKey string
+
+	// The key and order of the last confirmed checkout, so a repeated
+	// confirm lands on the same order.
+	CompletedKey     string
+	CompletedOrderID int
+}
+
+const (
+	OrderStatusPending   = "pending"
+	OrderStatusPaid      = "paid"
+	OrderStatusPacked    = "packed"
+	OrderStatusShipped   = "shipped"
+	OrderStatusDelivered = "delivered"
+	OrderStatusCancelled = "cancelled"
+	OrderStatusRefunded  = "refunded"
+)
+
+type OrderItem struct {
+	ProductID int     `json:"product_id"`
+	SKU       string  `json:"sku"`
+	Name      string  `json:"name"`
+	Quantity  int     `json:"quantity"`
+	UnitPrice float64 `json:"unit_price"`
+	Subtotal  float64 `json:"subtotal"`
+}
+
+type Order struct {
+	ID              int         `json:"id"`
+	Status          string      `json:"status"`
+	Items           []OrderItem `json:"items"`
+	ShippingAddress Address     `json:"shipping_address"`
+	ShippingMethod  string      `json:"shipping_method"`
+	ShippingCost    float64     `json:"shipping_cost"`
+	Subtotal        float64     `json:"subtotal"`
+	Total           float64     `json:"total"`
+	Carrier         string      `json:"carrier"`
+	TrackingNumber  string      `json:"tracking_number"`
+	TrackingURL     string      `json:"tracking_url"`
+	CreatedAt       time.Time   `json:"created_at"`
+}
+
+type CreateOrderRequest struct {
+	Items           []CartItem `json:"items"`
+	ShippingAddress Address    `json:"shipping_address"`
+	ShippingMethod  string     `json:"shipping_method"`
+}
+
+type SingleOrderResponse struct {
+	SharedResponse
+	Order Order `json:"data"`
+}
+
+type OrderListResponse struct {
+	SharedResponse
+	Orders []Order `json:"data"`
+	Meta   Meta    `json:"meta"`
+}
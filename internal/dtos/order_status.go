@@ -0,0 +1,108 @@
+package dtos
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tikimcrzx723/alejandrinasweb/internal/validator"
+)
+
+// orderTransitions lists the statuses an order may move to from each status.
+var orderTransitions = map[string][]string{
+	OrderStatusPending:   {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:      {OrderStatusPacked, OrderStatusCancelled, OrderStatusRefunded},
+	OrderStatusPacked:    {OrderStatusShipped, OrderStatusCancelled, OrderStatusRefunded},
+	OrderStatusShipped:   {OrderStatusDelivered, OrderStatusRefunded},
+	OrderStatusDelivered: {OrderStatusRefunded},
+	OrderStatusCancelled: {},
+	OrderStatusRefunded:  {},
+}
+
+// OrderStatuses returns every known order status in lifecycle order.
+func OrderStatuses() []string {
+	return []string{
+		OrderStatusPending,
+		OrderStatusPaid,
+		OrderStatusPacked,
+		OrderStatusShipped,
+		OrderStatusDelivered,
+		OrderStatusCancelled,
+		OrderStatusRefunded,
+	}
+}
+
+// NextOrderStatuses returns the statuses an order in status from may move to.
+func NextOrderStatuses(from string) []string {
+	return orderTransitions[from]
+}
+
+func CanTransitionOrder(from, to string) bool {
+	return slices.Contains(orderTransitions[from], to)
+}
+
+type OrderFilter struct {
+	Status string `query:"status"`
+	From   string `query:"from"`
+	To     string `query:"to"`
+	Page   int    `query:"page"`
+	Limit  int    `query:"-"`
+}
+
+func (f OrderFilter) Validate(v *validator.Validator) {
+	if f.Status != "" {
+		v.Check(validator.PermittedValue(f.Status, OrderStatuses()...), "status", "Estado desconocido")
+	}
+
+	from, fromErr := time.Parse(time.DateOnly, f.From)
+	if f.From != "" {
+		v.Check(fromErr == nil, "from", "Fecha inválida")
+	}
+
+	to, toErr := time.Parse(time.DateOnly, f.To)
+	if f.To != "" {
+		v.Check(toErr == nil, "to", "Fecha inválida")
+	}
+
+	if f.From != "" && f.To != "" && fromErr == nil && toErr == nil {
+		v.Check(!to.Before(from), "to", "La fecha final es anterior a la inicial")
+	}
+}
+
+type UpdateOrderStatusForm struct {
+	Status         string `form:"status"`
+	Reason         string `form:"reason"`
+	Carrier        string `form:"carrier"`
+	TrackingNumber string `form:"tracking_number"`
+}
+
+// Validate checks the form against the order's current status.
+func (f UpdateOrderStatusForm) Validate(v *validator.Validator, current string) {
+	v.Check(CanTransitionOrder(current, f.Status), "status", "No se puede pasar el pedido a ese estado")
+	v.Check(strings.TrimSpace(f.Reason) != "", "reason", "Indica el motivo del cambio")
+	if f.Status == OrderStatusShipped {
+		v.Check(strings.TrimSpace(f.TrackingNumber) != "", "tracking_number", "La guía de envío es obligatoria")
+	}
+}
+
+type UpdateOrderStatusRequest struct {
+	Status         string `json:"status"`
+	Reason         string `json:"reason"`
+	Carrier        string `json:"carrier,omitempty"`
+	TrackingNumber string `json:"tracking_number,omitempty"`
+}
+
+// OrderStatusChange is one entry of an order's audit trail.
+type OrderStatusChange struct {
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	ActorID    int       `json:"actor_id"`
+	ActorEmail string    `json:"actor_email"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type OrderHistoryResponse struct {
+	SharedResponse
+	History []OrderStatusChange `json:"data"`
+}
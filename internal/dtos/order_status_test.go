@@ -0,0 +1,47 @@
+package dtos
+
+import "testing"
+
+func TestCanTransitionOrder(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{OrderStatusPending, OrderStatusPaid, true},
+		{OrderStatusPending, OrderStatusCancelled, true},
+		{OrderStatusPaid, OrderStatusPacked, true},
+		{OrderStatusPaid, OrderStatusRefunded, true},
+		{OrderStatusPacked, OrderStatusShipped, true},
+		{OrderStatusShipped, OrderStatusDelivered, true},
+		{OrderStatusShipped, OrderStatusRefunded, true},
+		{OrderStatusDelivered, OrderStatusRefunded, true},
+
+		{OrderStatusPending, OrderStatusShipped, false},
+		{OrderStatusPending, OrderStatusRefunded, false},
+		{OrderStatusPaid, OrderStatusPending, false},
+		{OrderStatusShipped, OrderStatusCancelled, false},
+		{OrderStatusDelivered, OrderStatusShipped, false},
+		{OrderStatusCancelled, OrderStatusPaid, false},
+		{OrderStatusRefunded, OrderStatusPaid, false},
+		{OrderStatusPaid, OrderStatusPaid, false},
+		{OrderStatusPending, "lost", false},
+		{"lost", OrderStatusPaid, false},
+		{"", OrderStatusPaid, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			if got := CanTransitionOrder(tt.from, tt.to); got != tt.want {
+				t.Errorf("CanTransitionOrder(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderTransitionsCoverEveryStatus(t *testing.T) {
+	for _, status := range OrderStatuses() {
+		if _, ok := orderTransitions[status]; !ok {
+			t.Errorf("no transitions listed for %q", status)
+		}
+	}
+}
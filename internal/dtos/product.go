@@ -1,5 +1,14 @@
 package dtos
 
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tikimcrzx723/alejandrinasweb/internal/validator"
+)
+
 type CreateProductForm struct {
 	ID          int     `form:"product_id"`
 	Name        string  `form:"product_name"`
@@ -9,6 +18,19 @@ type CreateProductForm struct {
 	Description string  `form:"product_description"`
 }
 
+// Validate checks the form against categories, the categories that exist.
+func (f CreateProductForm) Validate(v *validator.Validator, categories []Category) {
+	v.Check(strings.TrimSpace(f.Name) != "", "product_name", "El nombre es obligatorio")
+	v.Check(utf8.RuneCountInString(f.Name) <= 200, "product_name", "El nombre no puede tener más de 200 caracteres")
+	v.Check(f.Price > 0, "product_price", "El precio debe ser mayor que cero")
+	v.Check(f.Stock >= 0, "product_stock", "La cantidad no puede ser negativa")
+	v.Check(utf8.RuneCountInString(f.Description) <= 2000, "product_description", "La descripción no puede tener más de 2000 caracteres")
+	v.Check(f.CategoryID != 0, "product_category", "Selecciona una categoría")
+	v.Check(f.CategoryID == 0 || slices.ContainsFunc(categories, func(c Category) bool {
+		return c.ID == f.CategoryID
+	}), "product_category", "La categoría no existe")
+}
+
 type CreateProductRequest struct {
 	Name        string  `json:"name"`
 	CategoryID  int     `json:"category_id"`
@@ -19,16 +41,17 @@ type CreateProductRequest struct {
 }
 
 type Product struct {
-	ID          int      `json:"id"`
-	Name        string   `json:"name"`
-	CategoryID  int      `json:"category_id"`
-	Price       float64  `json:"price"`
-	Images      []Image  `json:"images"`
-	IsActive    bool     `json:"is_active"`
-	SKU         string   `json:"sku"`
-	Stock       int      `json:"stock"`
-	Description string   `json:"description"`
-	Category    Category `json:"category"`
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	CategoryID  int       `json:"category_id"`
+	Price       float64   `json:"price"`
+	Images      []Image   `json:"images"`
+	IsActive    bool      `json:"is_active"`
+	SKU         string    `json:"sku"`
+	Stock       int       `json:"stock"`
+	Description string    `json:"description"`
+	Category    Category  `json:"category"`
+	CreatedAt   time.Time `json:"created_at"`
 }
 
 type Image struct {
@@ -69,3 +92,41 @@ type UpdateProductRequest struct {
 	Description string  `json:"description"`
 	SKU         string  `json:"sku"`
 }
+
+const (
+	ProductSortPriceAsc  = "price_asc"
+	ProductSortPriceDesc = "price_desc"
+	ProductSortName      = "name"
+	ProductSortNewest    = "newest"
+)
+
+// ProductQuery narrows a product listing. Zero values are left out of the
+// request so the backend applies its defaults; Active is nil for both active
+// and inactive products.
+type ProductQuery struct {
+	Page       int
+	Limit      int
+	Search     string
+	CategoryID int
+	Active     *bool
+	Sort       string
+	MinPrice   float64
+	MaxPrice   float64
+}
+
+type SearchForm struct {
+	Query    string  `query:"q"`
+	Category int     `query:"category"`
+	Sort     string  `query:"sort"`
+	MinPrice float64 `query:"min_price"`
+	MaxPrice float64 `query:"max_price"`
+	Page     int     `query:"page"`
+}
+
+type AdminProductsForm struct {
+	Query    string `query:"q"`
+	Category int    `query:"category"`
+	Status   string `query:"status"`
+	Sort     string `query:"sort"`
+	Page     int    `query:"page"`
+}
@@ -0,0 +1,14 @@
+package dtos
+
+import "time"
+
+// UserSession is a device where the user is logged in.
+type UserSession struct {
+	ID        string
+	UserAgent string
+	IP        string
+	CreatedAt time.Time
+	LastSeen  time.Time
+	// Current is the session of the request being served.
+	Current bool
+}
@@ -5,3 +5,10 @@ type SharedResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error"`
 }
+
+// ErrorResponse is the body the backend sends with non-2xx responses.
+type ErrorResponse struct {
+	SharedResponse
+	Code   string            `json:"code"`
+	Fields map[string]string `json:"errors"`
+}
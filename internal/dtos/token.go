@@ -1,5 +1,16 @@
 package dtos
 
+import (
+	"time"
+
+	"github.com/tikimcrzx723/alejandrinasweb/internal/validator"
+)
+
+const (
+	ScopePasswordReset = "password-reset"
+	ScopeActivation    = "activation"
+)
+
 type InsertToken struct {
 	Plaintext string `json:"token"`
 	Hash      []byte `json:"-"`
@@ -7,3 +18,61 @@ type InsertToken struct {
 	Expiry    int64  `json:"expiry"`
 	Scope     string `json:"-"`
 }
+
+// ExpiresAt returns Expiry, a Unix timestamp, as a time.
+func (t InsertToken) ExpiresAt() time.Time {
+	return time.Unix(t.Expiry, 0)
+}
+
+type CreateTokenRequest struct {
+	Email string `json:"email"`
+	Scope string `json:"scope"`
+}
+
+type TokenResponse struct {
+	SharedResponse
+	Token InsertToken `json:"data"`
+}
+
+// ResetPasswordResponse names the user whose password was reset.
+type ResetPasswordResponse struct {
+	SharedResponse
+	User User `json:"data"`
+}
+
+type ForgotPasswordForm struct {
+	Email string `form:"email"`
+}
+
+func (f ForgotPasswordForm) Validate(v *validator.Validator) {
+	v.Check(validator.Matches(f.Email, validator.EmailRX), "email", "Ingresa un correo válido")
+}
+
+type ResetPasswordForm struct {
+	Token           string `form:"token"`
+	Password        string `form:"password"`
+	ConfirmPassword string `form:"confirm_password"`
+}
+
+func (f ResetPasswordForm) Validate(v *validator.Validator) {
+	v.Check(f.Token != "", "token", "El enlace no es válido")
+	validatePassword(v, "password", f.Password)
+	v.Check(f.Password == f.ConfirmPassword, "confirm_password", "Las contraseñas no coinciden")
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+type ResendActivationForm struct {
+	Email string `form:"email"`
+}
+
+func (f ResendActivationForm) Validate(v *validator.Validator) {
+	v.Check(validator.Matches(f.Email, validator.EmailRX), "email", "Ingresa un correo válido")
+}
+
+type ActivateUserRequest struct {
+	Token string `json:"token"`
+}
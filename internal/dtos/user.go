@@ -1,6 +1,13 @@
 package dtos
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tikimcrzx723/alejandrinasweb/internal/validator"
+)
 
 type LoginRequest struct {
 	Email    string `json:"email"`
@@ -36,9 +43,39 @@ type RegisterUserForm struct {
 	Phone     string `form:"phone"`
 }
 
+func (f RegisterUserForm) Validate(v *validator.Validator) {
+	v.Check(strings.TrimSpace(f.FirstName) != "", "first_name", "El nombre es obligatorio")
+	v.Check(utf8.RuneCountInString(f.FirstName) <= 100, "first_name", "El nombre no puede tener más de 100 caracteres")
+	v.Check(strings.TrimSpace(f.LastName) != "", "last_name", "Los apellidos son obligatorios")
+	v.Check(utf8.RuneCountInString(f.LastName) <= 100, "last_name", "Los apellidos no pueden tener más de 100 caracteres")
+	v.Check(validator.Matches(f.Phone, validator.PhoneRX), "phone", "El teléfono no es válido")
+	v.Check(validator.Matches(f.Email, validator.EmailRX), "email", "Ingresa un correo válido")
+	validatePassword(v, "password", f.Password)
+}
+
 type LoginUserForm struct {
 	Email    string `form:"email"`
 	Password string `form:"password"`
+	Remember bool   `form:"remember"`
+	// Next is the page to return to after logging in.
+	Next string `form:"next"`
+}
+
+func (f LoginUserForm) Validate(v *validator.Validator) {
+	v.Check(validator.Matches(f.Email, validator.EmailRX), "email", "Ingresa un correo válido")
+	v.Check(f.Password != "", "password", "La contraseña es obligatoria")
+}
+
+// validatePassword applies the password policy to a new password. The upper
+// bound is bcrypt's input limit on the backend.
+func validatePassword(v *validator.Validator, key string, password string) {
+	v.Check(len(password) >= 8, key, "La contraseña debe tener al menos 8 caracteres")
+	v.Check(len(password) <= 72, key, "La contraseña no puede tener más de 72 caracteres")
+	v.Check(
+		strings.IndexFunc(password, unicode.IsLetter) >= 0 && strings.IndexFunc(password, unicode.IsDigit) >= 0,
+		key,
+		"La contraseña debe incluir letras y números",
+	)
 }
 
 type RegisterResponse struct {
@@ -57,3 +94,17 @@ type User struct {
 	Role      string `json:"role"`
 	IsActive  bool   `json:"is_active"`
 }
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type RefreshTokenResponse struct {
+	SharedResponse
+	Tokens TokenPair `json:"data"`
+}
@@ -0,0 +1,61 @@
+package dtos
+
+type WishlistItem struct {
+	ProductID int    `json:"product_id"`
+	SKU       string `json:"sku"`
+}
+
+type Wishlist struct {
+	Items []WishlistItem `json:"items"`
+}
+
+func (w Wishlist) Has(sku string) bool {
+	for _, item := range w.Items {
+		if item.SKU == sku {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SKUs returns the SKU of every item in the wishlist.
+func (w Wishlist) SKUs() []string {
+	skus := make([]string, 0, len(w.Items))
+	for _, item := range w.Items {
+		skus = append(skus, item.SKU)
+	}
+
+	return skus
+}
+
+// Toggle adds item when it is missing and removes it otherwise. It reports
+// whether the item is in the wishlist afterwards.
+func (w *Wishlist) Toggle(item WishlistItem) bool {
+	for i, existing := range w.Items {
+		if existing.SKU == item.SKU {
+			w.Items = append(w.Items[:i], w.Items[i+1:]...)
+			return false
+		}
+	}
+
+	w.Items = append(w.Items, item)
+	return true
+}
+
+type WishlistResponse struct {
+	SharedResponse
+	Wishlist Wishlist `json:"data"`
+}
+
+type AddWishlistItemRequest struct {
+	ProductID int `json:"product_id"`
+}
+
+type MergeWishlistRequest struct {
+	Items []WishlistItem `json:"items"`
+}
+
+type ToggleWishlistForm struct {
+	Next string `form:"next"`
+}
@@ -0,0 +1,101 @@
+// Package mailer delivers the emails sent by the web app.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrClosed is returned by Send after the mailer has been closed.
+var ErrClosed = errors.New("mailer closed")
+
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Outbox is a Mailer that writes every message as an .eml file in a local
+// directory instead of delivering it.
+type Outbox struct {
+	dir  string
+	from string
+
+	// mu is held for reading by every Send and for writing by Close, so
+	// Close waits for messages being written.
+	mu     sync.RWMutex
+	closed bool
+}
+
+var _ Mailer = (*Outbox)(nil)
+
+func NewOutbox(dir, from string) (*Outbox, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create outbox dir: %w", err)
+	}
+
+	return &Outbox{dir: dir, from: from}, nil
+}
+
+func (o *Outbox) Send(ctx context.Context, msg Message) error {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	if o.closed {
+		return ErrClosed
+	}
+
+	now := time.Now().UTC()
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", o.from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(msg.Text)
+
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102T150405"), uuid.NewString())
+	path := filepath.Join(o.dir, name)
+	if err := os.WriteFile(path, b.Bytes(), 0o640); err != nil {
+		return fmt.Errorf("write outbox message: %w", err)
+	}
+
+	slog.InfoContext(ctx, "email written to outbox", "to", msg.To, "subject", msg.Subject, "file", path)
+
+	return nil
+}
+
+// Close waits for the messages being written and makes later sends fail, so
+// no half-written file is left behind on shutdown.
+func (o *Outbox) Close(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		o.mu.Lock()
+		o.closed = true
+		o.mu.Unlock()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("close outbox: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,87 @@
+package search
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+)
+
+// FetchFunc reads the active catalog from the backend. complete is false when
+// only part of it could be read.
+type FetchFunc func(ctx context.Context) (products []dtos.Product, complete bool, err error)
+
+// Catalog keeps the active catalog in memory, with each product's search text
+// already normalized, so that searches and page changes don't refetch it. It
+// is refreshed once it is older than its TTL or after Invalidate.
+type Catalog struct {
+	fetch FetchFunc
+	ttl   time.Duration
+
+	mu        sync.Mutex
+	entries   []entry
+	complete  bool
+	fetchedAt time.Time
+}
+
+type entry struct {
+	product dtos.Product
+	text    string
+}
+
+func NewCatalog(fetch FetchFunc, ttl time.Duration) *Catalog {
+	return &Catalog{fetch: fetch, ttl: ttl}
+}
+
+// Search returns the products matching form, ordered by form.Sort. complete
+// is false when the cached catalog is only part of the backend's.
+func (c *Catalog) Search(ctx context.Context, form dtos.SearchForm) ([]dtos.Product, bool, error) {
+	entries, complete, err := c.load(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return match(entries, form), complete, nil
+}
+
+// Invalidate drops the cached catalog so the next search reads it again.
+func (c *Catalog) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.fetchedAt = time.Time{}
+}
+
+// load returns the cached catalog, reading it again when it is stale. Callers
+// wait on the lock while it is read so that only one of them hits the
+// backend. A stale copy is served when the backend can't be reached.
+func (c *Catalog) load(ctx context.Context) ([]entry, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.fetchedAt.IsZero() && time.Since(c.fetchedAt) < c.ttl {
+		return c.entries, c.complete, nil
+	}
+
+	products, complete, err := c.fetch(ctx)
+	if err != nil {
+		if c.entries == nil {
+			return nil, false, err
+		}
+		slog.WarnContext(ctx, "serving a stale search catalog", "err", err)
+		return c.entries, c.complete, nil
+	}
+
+	entries := make([]entry, 0, len(products))
+	for _, p := range products {
+		entries = append(entries, entry{product: p, text: searchText(p)})
+	}
+
+	c.entries = entries
+	c.complete = complete
+	c.fetchedAt = time.Now()
+
+	return c.entries, c.complete, nil
+}
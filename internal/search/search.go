@@ -0,0 +1,96 @@
+// Package search matches and orders catalog products for the storefront
+// search page.
+package search
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/gosimple/unidecode"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+)
+
+const (
+	SortRelevance = ""
+	SortPriceAsc  = dtos.ProductSortPriceAsc
+	SortPriceDesc = dtos.ProductSortPriceDesc
+	SortName      = dtos.ProductSortName
+	SortNewest    = dtos.ProductSortNewest
+)
+
+// Normalize lowercases s, strips accents and collapses whitespace so that
+// "Crema Hidratánte" and "crema  hidratante" compare equal.
+func Normalize(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(unidecode.Unidecode(s))), " ")
+}
+
+func searchText(p dtos.Product) string {
+	return Normalize(strings.Join([]string{p.Name, p.SKU, p.Description, p.Category.Name}, " "))
+}
+
+// match returns the active products matching every term of form.Query,
+// restricted to form.Category and the form's price range when set and
+// ordered by form.Sort.
+func match(entries []entry, form dtos.SearchForm) []dtos.Product {
+	terms := strings.Fields(Normalize(form.Query))
+
+	matches := make([]dtos.Product, 0, len(entries))
+	for _, e := range entries {
+		p := e.product
+		if !p.IsActive {
+			continue
+		}
+		if form.Category != 0 && p.CategoryID != form.Category {
+			continue
+		}
+		if (form.MinPrice > 0 && p.Price < form.MinPrice) || (form.MaxPrice > 0 && p.Price > form.MaxPrice) {
+			continue
+		}
+		if slices.ContainsFunc(terms, func(term string) bool { return !strings.Contains(e.text, term) }) {
+			continue
+		}
+
+		matches = append(matches, p)
+	}
+
+	sortProducts(matches, form.Sort)
+
+	return matches
+}
+
+func sortProducts(products []dtos.Product, sort string) {
+	switch sort {
+	case SortPriceAsc:
+		slices.SortStableFunc(products, func(a, b dtos.Product) int { return cmp.Compare(a.Price, b.Price) })
+	case SortPriceDesc:
+		slices.SortStableFunc(products, func(a, b dtos.Product) int { return cmp.Compare(b.Price, a.Price) })
+	case SortName:
+		slices.SortStableFunc(products, func(a, b dtos.Product) int { return cmp.Compare(Normalize(a.Name), Normalize(b.Name)) })
+	case SortNewest:
+		slices.SortStableFunc(products, func(a, b dtos.Product) int {
+			if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+				return c
+			}
+			return cmp.Compare(b.ID, a.ID)
+		})
+	}
+}
+
+// Page returns the products on page (1-based) and the matching pagination
+// metadata. A page past the end is clamped to the last one.
+func Page(products []dtos.Product, page, limit int) ([]dtos.Product, dtos.Meta) {
+	totalPages := (len(products) + limit - 1) / limit
+	page = max(1, min(page, totalPages))
+	meta := dtos.Meta{
+		Page:       page,
+		Limit:      limit,
+		Total:      len(products),
+		TotalPages: totalPages,
+	}
+
+	start := min((page-1)*limit, len(products))
+	end := min(start+limit, len(products))
+
+	return products[start:end], meta
+}
@@ -0,0 +1,118 @@
+package sessionstore
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Memory is a Backend that keeps sessions in the process. They are lost on
+// restart and not shared between instances, so it is meant for development
+// and tests.
+type Memory struct {
+	mu       sync.Mutex
+	sessions map[string]Record
+}
+
+var _ Backend = (*Memory)(nil)
+
+func NewMemory() *Memory {
+	return &Memory{sessions: map[string]Record{}}
+}
+
+func (m *Memory) Get(_ context.Context, id string) (Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rec, ok := m.sessions[id]
+	if !ok || !rec.ExpiresAt.After(time.Now()) {
+		return Record{}, ErrNotFound
+	}
+
+	return rec, nil
+}
+
+func (m *Memory) Put(_ context.Context, rec Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if old, ok := m.sessions[rec.ID]; ok {
+		rec.CreatedAt = old.CreatedAt
+	}
+	m.sessions[rec.ID] = rec
+
+	return nil
+}
+
+func (m *Memory) Delete(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sessions, id)
+
+	return nil
+}
+
+func (m *Memory) ListByUser(_ context.Context, userID int) ([]Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	var recs []Record
+	for _, rec := range m.sessions {
+		if rec.UserID == userID && rec.ExpiresAt.After(now) {
+			recs = append(recs, rec)
+		}
+	}
+	slices.SortFunc(recs, func(a, b Record) int {
+		return cmp.Compare(b.UpdatedAt.UnixNano(), a.UpdatedAt.UnixNano())
+	})
+
+	return recs, nil
+}
+
+func (m *Memory) DeleteForUser(_ context.Context, userID int, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rec, ok := m.sessions[id]
+	if !ok || rec.UserID != userID {
+		return ErrNotFound
+	}
+	delete(m.sessions, id)
+
+	return nil
+}
+
+func (m *Memory) DeleteOthersForUser(_ context.Context, userID int, keepID string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var n int64
+	for id, rec := range m.sessions {
+		if rec.UserID == userID && id != keepID {
+			delete(m.sessions, id)
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+func (m *Memory) DeleteExpired(_ context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	var n int64
+	for id, rec := range m.sessions {
+		if !rec.ExpiresAt.After(now) {
+			delete(m.sessions, id)
+			n++
+		}
+	}
+
+	return n, nil
+}
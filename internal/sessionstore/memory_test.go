@@ -0,0 +1,201 @@
+package sessionstore
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// newTestMemory returns a Memory holding, for user 1, the live sessions "a"
+// and "b" ("b" used last) and the expired session "old"; for user 2 the
+// session "c"; and the guest session "guest".
+func newTestMemory(t *testing.T) *Memory {
+	t.Helper()
+
+	now := time.Now()
+	m := NewMemory()
+	for _, rec := range []Record{
+		{ID: "a", UserID: 1, UpdatedAt: now.Add(-2 * time.Minute), ExpiresAt: now.Add(time.Hour)},
+		{ID: "b", UserID: 1, UpdatedAt: now.Add(-time.Minute), ExpiresAt: now.Add(time.Hour)},
+		{ID: "old", UserID: 1, UpdatedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(-time.Hour)},
+		{ID: "c", UserID: 2, UpdatedAt: now, ExpiresAt: now.Add(time.Hour)},
+		{ID: "guest", UpdatedAt: now, ExpiresAt: now.Add(time.Hour)},
+	} {
+		if err := m.Put(context.Background(), rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return m
+}
+
+func ids(recs []Record) []string {
+	out := make([]string, 0, len(recs))
+	for _, rec := range recs {
+		out = append(out, rec.ID)
+	}
+
+	return out
+}
+
+func TestMemoryGet(t *testing.T) {
+	tests := []struct {
+		id      string
+		wantErr error
+	}{
+		{"a", nil},
+		{"guest", nil},
+		{"old", ErrNotFound},
+		{"missing", ErrNotFound},
+	}
+
+	m := newTestMemory(t)
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			rec, err := m.Get(context.Background(), tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Get(%q) error = %v, want %v", tt.id, err, tt.wantErr)
+			}
+			if err == nil && rec.ID != tt.id {
+				t.Errorf("Get(%q) returned %q", tt.id, rec.ID)
+			}
+		})
+	}
+}
+
+func TestMemoryPutKeepsCreatedAt(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+	created := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	if err := m.Put(ctx, Record{ID: "a", CreatedAt: created, ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Put(ctx, Record{ID: "a", UserID: 1, CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := m.Get(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rec.CreatedAt.Equal(created) {
+		t.Errorf("CreatedAt = %v, want %v", rec.CreatedAt, created)
+	}
+	if rec.UserID != 1 {
+		t.Errorf("UserID = %d, want the replaced record's 1", rec.UserID)
+	}
+}
+
+func TestMemoryListByUser(t *testing.T) {
+	tests := []struct {
+		userID int
+		want   []string
+	}{
+		{1, []string{"b", "a"}},
+		{2, []string{"c"}},
+		{3, []string{}},
+	}
+
+	m := newTestMemory(t)
+	for _, tt := range tests {
+		recs, err := m.ListByUser(context.Background(), tt.userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := ids(recs); !slices.Equal(got, tt.want) {
+			t.Errorf("ListByUser(%d) = %v, want %v", tt.userID, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryDeleteForUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  int
+		id      string
+		wantErr error
+		kept    bool
+	}{
+		{"own session", 1, "a", nil, false},
+		{"another user's session", 1, "c", ErrNotFound, true},
+		{"guest session", 1, "guest", ErrNotFound, true},
+		{"missing session", 1, "missing", ErrNotFound, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m := newTestMemory(t)
+
+			err := m.DeleteForUser(ctx, tt.userID, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DeleteForUser(%d, %q) error = %v, want %v", tt.userID, tt.id, err, tt.wantErr)
+			}
+
+			if _, err := m.Get(ctx, tt.id); (err == nil) != tt.kept {
+				t.Errorf("session %q kept = %v, want %v", tt.id, err == nil, tt.kept)
+			}
+		})
+	}
+}
+
+func TestMemoryDeleteOthersForUser(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID int
+		keepID string
+		want   int64
+		left   []string
+	}{
+		{"keep current session", 1, "b", 2, []string{"b", "c", "guest"}},
+		{"revoke every session", 1, "", 3, []string{"c", "guest"}},
+		{"user without sessions", 3, "", 0, []string{"a", "b", "c", "guest"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m := newTestMemory(t)
+
+			n, err := m.DeleteOthersForUser(ctx, tt.userID, tt.keepID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != tt.want {
+				t.Errorf("deleted %d sessions, want %d", n, tt.want)
+			}
+
+			var left []string
+			for _, id := range []string{"a", "b", "c", "guest"} {
+				if _, err := m.Get(ctx, id); err == nil {
+					left = append(left, id)
+				}
+			}
+			if !slices.Equal(left, tt.left) {
+				t.Errorf("sessions left = %v, want %v", left, tt.left)
+			}
+		})
+	}
+}
+
+func TestMemoryDeleteExpired(t *testing.T) {
+	ctx := context.Background()
+	m := newTestMemory(t)
+
+	n, err := m.DeleteExpired(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("deleted %d sessions, want 1", n)
+	}
+	if n, _ := m.DeleteExpired(ctx); n != 0 {
+		t.Errorf("second pass deleted %d sessions, want 0", n)
+	}
+	if len(m.sessions) != 4 {
+		t.Errorf("%d sessions left, want 4", len(m.sessions))
+	}
+}
@@ -0,0 +1,123 @@
+package sessionstore
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Postgres is a Backend that keeps sessions in the web_sessions table. See
+// migrations/000001_create_web_sessions.up.sql.
+type Postgres struct {
+	pool *pgxpool.Pool
+}
+
+var _ Backend = (*Postgres)(nil)
+
+func NewPostgres(pool *pgxpool.Pool) *Postgres {
+	return &Postgres{pool: pool}
+}
+
+const recordColumns = `id, name, COALESCE(user_id, 0), data, user_agent, ip, created_at, updated_at, expires_at`
+
+func scanRecord(row pgx.Row) (Record, error) {
+	var rec Record
+	err := row.Scan(
+		&rec.ID,
+		&rec.Name,
+		&rec.UserID,
+		&rec.Data,
+		&rec.UserAgent,
+		&rec.IP,
+		&rec.CreatedAt,
+		&rec.UpdatedAt,
+		&rec.ExpiresAt,
+	)
+
+	return rec, err
+}
+
+func (p *Postgres) Get(ctx context.Context, id string) (Record, error) {
+	row := p.pool.QueryRow(ctx, `
+		SELECT `+recordColumns+`
+		FROM web_sessions
+		WHERE id = $1 AND expires_at > now()`, id)
+
+	rec, err := scanRecord(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Record{}, ErrNotFound
+	}
+
+	return rec, err
+}
+
+func (p *Postgres) Put(ctx context.Context, rec Record) error {
+	_, err := p.pool.Exec(ctx, `
+		INSERT INTO web_sessions (id, name, user_id, data, user_agent, ip, created_at, updated_at, expires_at)
+		VALUES ($1, $2, NULLIF($3, 0), $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			data = EXCLUDED.data,
+			user_agent = EXCLUDED.user_agent,
+			ip = EXCLUDED.ip,
+			updated_at = EXCLUDED.updated_at,
+			expires_at = EXCLUDED.expires_at`,
+		rec.ID, rec.Name, rec.UserID, rec.Data, rec.UserAgent, rec.IP,
+		rec.CreatedAt, rec.UpdatedAt, rec.ExpiresAt,
+	)
+
+	return err
+}
+
+func (p *Postgres) Delete(ctx context.Context, id string) error {
+	_, err := p.pool.Exec(ctx, `DELETE FROM web_sessions WHERE id = $1`, id)
+
+	return err
+}
+
+func (p *Postgres) ListByUser(ctx context.Context, userID int) ([]Record, error) {
+	rows, err := p.pool.Query(ctx, `
+		SELECT `+recordColumns+`
+		FROM web_sessions
+		WHERE user_id = $1 AND expires_at > now()
+		ORDER BY updated_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (Record, error) {
+		return scanRecord(row)
+	})
+}
+
+func (p *Postgres) DeleteForUser(ctx context.Context, userID int, id string) error {
+	tag, err := p.pool.Exec(ctx, `DELETE FROM web_sessions WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (p *Postgres) DeleteOthersForUser(ctx context.Context, userID int, keepID string) (int64, error) {
+	tag, err := p.pool.Exec(ctx, `DELETE FROM web_sessions WHERE user_id = $1 AND id <> $2`, userID, keepID)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (p *Postgres) DeleteExpired(ctx context.Context) (int64, error) {
+	tag, err := p.pool.Exec(ctx, `DELETE FROM web_sessions WHERE expires_at <= now()`)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,281 @@
+// Package sessionstore keeps sessions on the server. The cookie only carries
+// a signed session ID, so a session can be listed and revoked.
+package sessionstore
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/securecookie"
+	"github.com/gorilla/sessions"
+)
+
+// ErrNotFound is returned by a Backend when a session does not exist or has
+// expired.
+var ErrNotFound = errors.New("session not found")
+
+// browserSessionTTL is how long a session saved with MaxAge 0, which the
+// browser drops when it closes, is kept on the server after its last save.
+const browserSessionTTL = 24 * time.Hour
+
+// Record is a session as it is stored.
+type Record struct {
+	ID   string
+	Name string
+	// UserID is the user the session belongs to, 0 for guests.
+	UserID    int
+	Data      []byte
+	UserAgent string
+	IP        string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Backend stores session records. Expired records must never be returned.
+type Backend interface {
+	Get(ctx context.Context, id string) (Record, error)
+	// Put creates the record or replaces it, keeping its CreatedAt.
+	Put(ctx context.Context, rec Record) error
+	Delete(ctx context.Context, id string) error
+	// ListByUser returns the sessions of a user, most recently used first.
+	ListByUser(ctx context.Context, userID int) ([]Record, error)
+	// DeleteForUser deletes the session id only if it belongs to userID.
+	DeleteForUser(ctx context.Context, userID int, id string) error
+	// DeleteOthersForUser deletes every session of userID except keepID.
+	DeleteOthersForUser(ctx context.Context, userID int, keepID string) (int64, error)
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+// Store is a sessions.Store that keeps the values of every session in a
+// Backend. Like sessions.CookieStore it takes key pairs: the first pair signs
+// new cookies and the others are only used to read old ones.
+type Store struct {
+	Options *sessions.Options
+	// UserID returns the user a session belongs to, or 0. Sessions with a
+	// user can be listed and revoked through the Store.
+	UserID func(*sessions.Session) int
+
+	backend Backend
+	codecs  []securecookie.Codec
+}
+
+var _ sessions.Store = (*Store)(nil)
+
+func New(backend Backend, keyPairs ...[]byte) *Store {
+	s := &Store{
+		Options: &sessions.Options{
+			Path:   "/",
+			MaxAge: 86400 * 30,
+		},
+		backend: backend,
+		codecs:  securecookie.CodecsFromPairs(keyPairs...),
+	}
+	s.MaxAge(s.Options.MaxAge)
+
+	return s
+}
+
+// MaxAge sets the maximum age of the store and of its cookies.
+func (s *Store) MaxAge(age int) {
+	s.Options.MaxAge = age
+	for _, codec := range s.codecs {
+		if sc, ok := codec.(*securecookie.SecureCookie); ok {
+			sc.MaxAge(age)
+		}
+	}
+}
+
+// Get returns the session called name, once per request.
+func (s *Store) Get(r *http.Request, name string) (*sessions.Session, error) {
+	return sessions.GetRegistry(r).Get(s, name)
+}
+
+// New returns the session called name. A missing, forged, expired or revoked
+// session yields a new empty one rather than an error, so the visitor simply
+// starts over.
+func (s *Store) New(r *http.Request, name string) (*sessions.Session, error) {
+	session := sessions.NewSession(s, name)
+	opts := *s.Options
+	session.Options = &opts
+	session.IsNew = true
+
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return session, nil
+	}
+
+	var id string
+	if err := securecookie.DecodeMulti(name, cookie.Value, &id, s.codecs...); err != nil {
+		return session, nil
+	}
+
+	rec, err := s.backend.Get(r.Context(), id)
+	if errors.Is(err, ErrNotFound) {
+		return session, nil
+	}
+	if err != nil {
+		return session, fmt.Errorf("load session: %w", err)
+	}
+
+	if err := (securecookie.GobEncoder{}).Deserialize(rec.Data, &session.Values); err != nil {
+		return session, fmt.Errorf("decode session: %w", err)
+	}
+	session.ID = id
+	session.IsNew = false
+
+	return session, nil
+}
+
+// Save stores the session and sets its cookie. A negative MaxAge deletes it.
+func (s *Store) Save(r *http.Request, w http.ResponseWriter, session *sessions.Session) error {
+	if session.Options.MaxAge < 0 {
+		if session.ID != "" {
+			if err := s.backend.Delete(r.Context(), session.ID); err != nil {
+				return fmt.Errorf("delete session: %w", err)
+			}
+		}
+		http.SetCookie(w, sessions.NewCookie(session.Name(), "", session.Options))
+		return nil
+	}
+
+	if session.ID == "" {
+		session.ID = rand.Text()
+	}
+
+	data, err := (securecookie.GobEncoder{}).Serialize(session.Values)
+	if err != nil {
+		return fmt.Errorf("encode session: %w", err)
+	}
+
+	now := time.Now()
+	ttl := browserSessionTTL
+	if session.Options.MaxAge > 0 {
+		ttl = time.Duration(session.Options.MaxAge) * time.Second
+	}
+
+	rec := Record{
+		ID:        session.ID,
+		Name:      session.Name(),
+		Data:      data,
+		UserAgent: r.UserAgent(),
+		IP:        clientIP(r),
+		CreatedAt: now,
+		UpdatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	if s.UserID != nil {
+		rec.UserID = s.UserID(session)
+	}
+	if err := s.backend.Put(r.Context(), rec); err != nil {
+		return fmt.Errorf("store session: %w", err)
+	}
+
+	encoded, err := securecookie.EncodeMulti(session.Name(), session.ID, s.codecs...)
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, sessions.NewCookie(session.Name(), encoded, session.Options))
+
+	return nil
+}
+
+// Regenerate deletes the stored record of session and clears its ID, so the
+// next Save stores its values under a new ID. Call it on login, so that an ID
+// planted in the visitor's browser beforehand is worthless.
+func (s *Store) Regenerate(ctx context.Context, session *sessions.Session) error {
+	if session.ID != "" {
+		if err := s.backend.Delete(ctx, session.ID); err != nil {
+			return fmt.Errorf("delete session: %w", err)
+		}
+	}
+	session.ID = ""
+
+	return nil
+}
+
+// UserSessions returns the sessions of a user, most recently used first.
+func (s *Store) UserSessions(ctx context.Context, userID int) ([]Record, error) {
+	if userID <= 0 {
+		return nil, nil
+	}
+
+	return s.backend.ListByUser(ctx, userID)
+}
+
+// RevokeSession logs out one session of a user. It returns ErrNotFound when
+// id is not one of their sessions.
+func (s *Store) RevokeSession(ctx context.Context, userID int, id string) error {
+	if userID <= 0 {
+		return ErrNotFound
+	}
+
+	return s.backend.DeleteForUser(ctx, userID, id)
+}
+
+// RevokeOtherSessions logs a user out everywhere but in keepID and returns
+// how many sessions were closed.
+func (s *Store) RevokeOtherSessions(ctx context.Context, userID int, keepID string) (int64, error) {
+	if userID <= 0 {
+		return 0, nil
+	}
+
+	return s.backend.DeleteOthersForUser(ctx, userID, keepID)
+}
+
+// RevokeUserSessions logs a user out everywhere and returns how many
+// sessions were closed.
+func (s *Store) RevokeUserSessions(ctx context.Context, userID int) (int64, error) {
+	if userID <= 0 {
+		return 0, nil
+	}
+
+	return s.backend.DeleteOthersForUser(ctx, userID, "")
+}
+
+// RunCleanup deletes expired sessions every interval until ctx is done.
+func (s *Store) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n, err := s.backend.DeleteExpired(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "could not delete expired sessions", "err", err)
+			continue
+		}
+		if n > 0 {
+			slog.InfoContext(ctx, "deleted expired sessions", "count", n)
+		}
+	}
+}
+
+// clientIP returns the address of the visitor, as reported by the reverse
+// proxy when there is one.
+func clientIP(r *http.Request) string {
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return ip
+	}
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		first, _, _ := strings.Cut(fwd, ",")
+		return strings.TrimSpace(first)
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+
+	return r.RemoteAddr
+}
@@ -1,10 +1,23 @@
 package main
 
 import (
+	"context"
 	"encoding/gob"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/tikimcrzx723/alejandrinasweb/internal/env"
+	"github.com/gorilla/sessions"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tikimcrzx723/alejandrinasweb/controllers"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/api"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/config"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/mailer"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/sessionstore"
 	"github.com/tikimcrzx723/alejandrinasweb/routes"
 	"github.com/tikimcrzx723/alejandrinasweb/routes/contexts"
 	"github.com/tikimcrzx723/alejandrinasweb/server"
@@ -13,12 +26,115 @@ import (
 func main() {
 	gob.Register(uuid.UUID{})
 	gob.Register(contexts.FlashMessage{})
+	gob.Register([]dtos.CartItem{})
+	gob.Register([]dtos.WishlistItem{})
+	gob.Register(dtos.CheckoutState{})
 
-	routes := routes.NewRoutes()
-	host := env.GetString("SERVER_HOST", "0.0.0.0")
-	port := env.GetInt("SERVER_PORT", 9090)
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Error("invalid configuration", "err", err)
+		os.Exit(1)
+	}
+	cfg.LogSecurity(slog.Default())
 
-	srv := server.NewServer(host, int32(port), routes.Load())
+	client, err := api.NewClient(
+		cfg.API.URL,
+		api.WithTimeout(cfg.API.Timeout),
+		api.WithServiceKey(cfg.API.ServiceKey),
+		api.WithTokenSource(contexts.ExtractToken),
+		api.WithTokenRefresher(controllers.SessionTokens{}),
+	)
+	if err != nil {
+		slog.Error("could not create api client", "err", err)
+		os.Exit(1)
+	}
 
-	srv.Start()
+	outbox, err := mailer.NewOutbox(cfg.Mail.OutboxDir, cfg.Mail.From)
+	if err != nil {
+		slog.Error("could not create mail outbox", "err", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	opts := []server.Option{
+		server.WithReadTimeout(cfg.Server.ReadTimeout),
+		server.WithWriteTimeout(cfg.Server.WriteTimeout),
+		server.WithIdleTimeout(cfg.Server.IdleTimeout),
+		server.WithShutdownTimeout(cfg.Server.ShutdownTimeout),
+		server.OnShutdown("mail outbox", outbox.Close),
+	}
+
+	// Signed cookies are rejected once they are older than the store's max
+	// age, so it must cover remembered logins.
+	maxAge := int(max(cfg.Session.RememberLifetime, 30*24*time.Hour).Seconds())
+
+	cookieStore := sessions.NewCookieStore(cfg.Session.KeyPairs()...)
+	cookieStore.MaxAge(maxAge)
+	cookieStore.Options = sessionOptions(cfg.Session.Cookie, maxAge)
+
+	var store sessions.Store = cookieStore
+	var sessionManager controllers.SessionManager
+	if cfg.Session.Store != config.SessionStoreCookie {
+		backend, closeBackend, err := openSessionBackend(ctx, cfg)
+		if err != nil {
+			slog.Error("could not open session store", "store", cfg.Session.Store, "err", err)
+			os.Exit(1)
+		}
+		opts = append(opts, server.OnShutdown("session store", closeBackend))
+
+		serverStore := sessionstore.New(backend, cfg.Session.KeyPairs()...)
+		serverStore.MaxAge(maxAge)
+		serverStore.Options = sessionOptions(cfg.Session.Cookie, maxAge)
+		serverStore.UserID = controllers.SessionUserID
+		go serverStore.RunCleanup(ctx, cfg.Session.CleanupInterval)
+		store, sessionManager = serverStore, serverStore
+	}
+
+	ctrl := controllers.New(client, outbox, sessionManager, cfg)
+	routes := routes.NewRoutes(ctrl, cfg, store)
+
+	srv := server.NewServer(cfg.Server.Host, int32(cfg.Server.Port), routes.Load(), opts...)
+	err = srv.Start(ctx)
+	stop()
+	if err != nil {
+		slog.Error("server error", "err", err)
+		os.Exit(1)
+	}
+}
+
+// openSessionBackend connects to the server-side session store selected by
+// SESSION_STORE. The returned function releases it on shutdown.
+func openSessionBackend(ctx context.Context, cfg config.Config) (sessionstore.Backend, func(context.Context) error, error) {
+	if cfg.Session.Store == config.SessionStoreMemory {
+		return sessionstore.NewMemory(), func(context.Context) error { return nil }, nil
+	}
+
+	pool, err := pgxpool.New(ctx, cfg.Database.URL)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, nil, err
+	}
+
+	closePool := func(context.Context) error {
+		pool.Close()
+		return nil
+	}
+
+	return sessionstore.NewPostgres(pool), closePool, nil
+}
+
+// sessionOptions are the default options of every session cookie.
+func sessionOptions(cookie config.Cookie, maxAge int) *sessions.Options {
+	return &sessions.Options{
+		Path:     "/",
+		Domain:   cookie.Domain,
+		MaxAge:   maxAge,
+		Secure:   cookie.Secure,
+		HttpOnly: true,
+		SameSite: cookie.SameSite,
+	}
 }
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS web_sessions (
+    id text PRIMARY KEY,
+    name text NOT NULL,
+    user_id bigint,
+    data bytea NOT NULL,
+    user_agent text NOT NULL DEFAULT '',
+    ip text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    expires_at timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS web_sessions_user_id_idx ON web_sessions (user_id) WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS web_sessions_expires_at_idx ON web_sessions (expires_at);
@@ -1,7 +1,10 @@
 package contexts
 
 import (
+	"slices"
+
 	"github.com/labstack/echo/v4"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/authz"
 )
 
 type AppKey struct{}
@@ -16,4 +19,35 @@ type App struct {
 	IsAuthenticated bool
 	Token           string
 	Role            string
+	// Permissions are what Role may do in the admin dashboard.
+	Permissions []authz.Permission
+	CSRFToken   string
+	CartCount   int
+	Wishlist    []string
+}
+
+// Can reports whether the user holds perm.
+func (a App) Can(perm authz.Permission) bool {
+	return a.IsAuthenticated && slices.Contains(a.Permissions, perm)
+}
+
+// IsStaff reports whether the user may open the admin dashboard at all.
+func (a App) IsStaff() bool {
+	return a.IsAuthenticated && len(a.Permissions) > 0
+}
+
+// RenewedTokenKey stores, on the echo context, an access token renewed while
+// handling the current request.
+type RenewedTokenKey struct{}
+
+func (RenewedTokenKey) String() string {
+	return "renewedToken"
+}
+
+// RequestIDKey stores, on the request context, the ID of the request that
+// is logged with errors and shown on error pages.
+type RequestIDKey struct{}
+
+func (RequestIDKey) String() string {
+	return "requestID"
 }
@@ -0,0 +1,14 @@
+package contexts
+
+type CategoriesKey struct{}
+
+func (CategoriesKey) String() string {
+	return "categories"
+}
+
+// UploadKey stores the config.Upload limits shown next to file inputs.
+type UploadKey struct{}
+
+func (UploadKey) String() string {
+	return "upload"
+}
@@ -2,7 +2,10 @@ package contexts
 
 import (
 	"context"
-	"fmt"
+
+	"github.com/tikimcrzx723/alejandrinasweb/internal/authz"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/config"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
 )
 
 func ExtractApp(ctx context.Context) App {
@@ -14,21 +17,62 @@ func ExtractApp(ctx context.Context) App {
 	return appCtx
 }
 
+// ExtractToken returns the backend access token for ctx, preferring one
+// renewed earlier in the same request.
 func ExtractToken(ctx context.Context) string {
-	return ExtractApp(ctx).Token
+	app := ExtractApp(ctx)
+	if app.Context != nil {
+		if token, ok := app.Get(RenewedTokenKey{}.String()).(string); ok && token != "" {
+			return token
+		}
+	}
+
+	return app.Token
+}
+
+// ExtractRequestID returns the ID of the request ctx belongs to, or "".
+func ExtractRequestID(ctx context.Context) string {
+	id, _ := ctx.Value(RequestIDKey{}).(string)
+	return id
+}
+
+func ExtractCSRFToken(ctx context.Context) string {
+	return ExtractApp(ctx).CSRFToken
 }
 
 func ExtractRole(ctx context.Context) string {
 	return ExtractApp(ctx).Role
 }
 
+// Can reports whether the user of ctx holds perm. Views use it to hide what
+// the user cannot use.
+func Can(ctx context.Context, perm authz.Permission) bool {
+	return ExtractApp(ctx).Can(perm)
+}
+
 func ExtractFlashMessages(ctx context.Context) []FlashMessage {
 	flashCtx, ok := ctx.Value(FlashKey{}).([]FlashMessage)
 	if !ok {
 		return []FlashMessage{}
 	}
 
-	fmt.Println(flashCtx)
-
 	return flashCtx
 }
+
+func ExtractCategories(ctx context.Context) []dtos.Category {
+	categories, ok := ctx.Value(CategoriesKey{}).([]dtos.Category)
+	if !ok {
+		return []dtos.Category{}
+	}
+
+	return categories
+}
+
+func ExtractUploadLimits(ctx context.Context) config.Upload {
+	upload, ok := ctx.Value(UploadKey{}).(config.Upload)
+	if !ok {
+		return config.Upload{}
+	}
+
+	return upload
+}
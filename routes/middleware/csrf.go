@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/securecookie"
+	"github.com/labstack/echo/v4"
+)
+
+// RotateCSRFKey lets gorilla/csrf accept token cookies signed with a previous
+// key. csrf.Protect only knows a single key, so a cookie that fails with key
+// but verifies with one of previous is re-signed with key before csrf.Protect
+// reads it, and sent back so the browser stops using the old signature.
+//
+// cookie describes the CSRF cookie as csrf.Protect writes it: name, path,
+// max age and flags.
+func RotateCSRFKey(key []byte, previous [][]byte, cookie http.Cookie) echo.MiddlewareFunc {
+	current := csrfCodec(key, cookie.MaxAge)
+	olds := make([]*securecookie.SecureCookie, 0, len(previous))
+	for _, k := range previous {
+		olds = append(olds, csrfCodec(k, cookie.MaxAge))
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if len(olds) == 0 {
+				return next(c)
+			}
+
+			r := c.Request()
+			old, err := r.Cookie(cookie.Name)
+			if err != nil {
+				return next(c)
+			}
+
+			var token []byte
+			if current.Decode(cookie.Name, old.Value, &token) == nil {
+				return next(c)
+			}
+
+			for _, codec := range olds {
+				if codec.Decode(cookie.Name, old.Value, &token) != nil {
+					continue
+				}
+
+				encoded, err := current.Encode(cookie.Name, token)
+				if err != nil {
+					break
+				}
+
+				replaceRequestCookie(r, cookie.Name, encoded)
+
+				renewed := cookie
+				renewed.Value = encoded
+				if renewed.MaxAge > 0 {
+					renewed.Expires = time.Now().Add(time.Duration(renewed.MaxAge) * time.Second)
+				}
+				c.SetCookie(&renewed)
+				break
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// csrfCodec builds the codec gorilla/csrf uses for its cookie: HMAC only,
+// with JSON serialization.
+func csrfCodec(key []byte, maxAge int) *securecookie.SecureCookie {
+	sc := securecookie.New(key, nil)
+	sc.SetSerializer(securecookie.JSONEncoder{})
+	sc.MaxAge(maxAge)
+
+	return sc
+}
+
+// replaceRequestCookie rewrites the Cookie header of r with a new value for
+// the cookie called name.
+func replaceRequestCookie(r *http.Request, name, value string) {
+	cookies := r.Cookies()
+	parts := make([]string, 0, len(cookies))
+	for _, ck := range cookies {
+		if ck.Name == name {
+			ck.Value = value
+		}
+		parts = append(parts, (&http.Cookie{Name: ck.Name, Value: ck.Value}).String())
+	}
+
+	r.Header.Set("Cookie", strings.Join(parts, "; "))
+}
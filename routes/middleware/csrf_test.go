@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestRotateCSRFKey(t *testing.T) {
+	var (
+		current  = bytes.Repeat([]byte{'c'}, 32)
+		previous = bytes.Repeat([]byte{'p'}, 32)
+		older    = bytes.Repeat([]byte{'o'}, 32)
+		unknown  = bytes.Repeat([]byte{'u'}, 32)
+		token    = []byte("csrf-token")
+	)
+	cookie := http.Cookie{Name: "_csrf", Path: "/", MaxAge: 3600, HttpOnly: true}
+
+	sign := func(t *testing.T, key []byte) string {
+		t.Helper()
+		encoded, err := csrfCodec(key, cookie.MaxAge).Encode(cookie.Name, token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return encoded
+	}
+
+	tests := []struct {
+		name     string
+		previous [][]byte
+		signedBy []byte // nil sends no CSRF cookie
+		renewed  bool
+	}{
+		{name: "no cookie", previous: [][]byte{previous}},
+		{name: "current key", previous: [][]byte{previous}, signedBy: current},
+		{name: "previous key", previous: [][]byte{previous}, signedBy: previous, renewed: true},
+		{name: "older previous key", previous: [][]byte{previous, older}, signedBy: older, renewed: true},
+		{name: "unknown key", previous: [][]byte{previous, older}, signedBy: unknown},
+		{name: "no previous keys", signedBy: previous},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.AddCookie(&http.Cookie{Name: "session", Value: "kept"})
+			sent := ""
+			if tt.signedBy != nil {
+				sent = sign(t, tt.signedBy)
+				req.AddCookie(&http.Cookie{Name: cookie.Name, Value: sent})
+			}
+			rec := httptest.NewRecorder()
+
+			var seen *http.Request
+			handler := RotateCSRFKey(current, tt.previous, cookie)(func(c echo.Context) error {
+				seen = c.Request()
+				return nil
+			})
+			if err := handler(echo.New().NewContext(req, rec)); err != nil {
+				t.Fatal(err)
+			}
+
+			if session, err := seen.Cookie("session"); err != nil || session.Value != "kept" {
+				t.Errorf("other cookies were not kept: %v, %v", session, err)
+			}
+
+			setCookie := rec.Header().Get("Set-Cookie")
+			if !tt.renewed {
+				if setCookie != "" {
+					t.Errorf("Set-Cookie = %q, want none", setCookie)
+				}
+				if got, err := seen.Cookie(cookie.Name); tt.signedBy != nil && (err != nil || got.Value != sent) {
+					t.Errorf("CSRF cookie was rewritten: %v, %v", got, err)
+				}
+				return
+			}
+
+			got, err := seen.Cookie(cookie.Name)
+			if err != nil {
+				t.Fatalf("CSRF cookie missing from the request: %v", err)
+			}
+			var decoded []byte
+			if err := csrfCodec(current, cookie.MaxAge).Decode(cookie.Name, got.Value, &decoded); err != nil {
+				t.Fatalf("request cookie is not signed with the current key: %v", err)
+			}
+			if !bytes.Equal(decoded, token) {
+				t.Errorf("token = %q, want %q", decoded, token)
+			}
+
+			renewed := rec.Result().Cookies()
+			if len(renewed) != 1 || renewed[0].Name != cookie.Name || renewed[0].Value != got.Value {
+				t.Fatalf("Set-Cookie = %q, want the re-signed CSRF cookie", setCookie)
+			}
+			if renewed[0].MaxAge != cookie.MaxAge || !renewed[0].HttpOnly || renewed[0].Path != cookie.Path {
+				t.Errorf("renewed cookie flags = %+v, want those of %+v", renewed[0], cookie)
+			}
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/tikimcrzx723/alejandrinasweb/controllers"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/api"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/authz"
+	"github.com/tikimcrzx723/alejandrinasweb/routes/contexts"
 
 	"github.com/labstack/echo-contrib/session"
 	"github.com/labstack/echo/v4"
@@ -13,7 +17,7 @@ func RequireAuth(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		sess, err := session.Get(controllers.AuthSessionName, c)
 		if err != nil {
-			return c.Redirect(http.StatusTemporaryRedirect, "/login")
+			return loginRequired(c)
 		}
 
 		isAuth, _ := sess.Values[controllers.AuthUserAuthenticated].(bool)
@@ -21,10 +25,12 @@ func RequireAuth(next echo.HandlerFunc) echo.HandlerFunc {
 			return next(c)
 		}
 
-		return c.Redirect(http.StatusTemporaryRedirect, "/login")
+		return loginRequired(c)
 	}
 }
 
+// RequireNoAuth keeps logged-in users off the login and register pages,
+// sending them where the login would have.
 func RequireNoAuth(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		sess, err := session.Get(controllers.AuthSessionName, c)
@@ -34,25 +40,89 @@ func RequireNoAuth(next echo.HandlerFunc) echo.HandlerFunc {
 
 		isAuth, _ := sess.Values[controllers.AuthUserAuthenticated].(bool)
 		if isAuth {
-			return c.Redirect(http.StatusTemporaryRedirect, "/")
+			return c.Redirect(http.StatusSeeOther, controllers.LoginRedirect(c.QueryParam("next")))
 		}
 
 		return next(c)
 	}
 }
 
-func RequireAdminRole(next echo.HandlerFunc) echo.HandlerFunc {
+// Authorizer guards the admin dashboard by permission. Visitors are sent to
+// log in; logged-in users without the permission get Forbidden.
+type Authorizer struct {
+	Forbidden echo.HandlerFunc
+}
+
+// RequireStaff lets through users whose role grants any permission.
+func (a Authorizer) RequireStaff(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		sess, err := session.Get(controllers.AuthSessionName, c)
-		if err != nil {
-			return c.Redirect(http.StatusTemporaryRedirect, "/login")
+		app := contexts.ExtractApp(c.Request().Context())
+		if !app.IsAuthenticated {
+			return loginRequired(c)
+		}
+		if !app.IsStaff() {
+			return accessDenied(c, a.Forbidden)
 		}
 
-		role, _ := sess.Values["ROLE"].(string)
-		if role == "admin" {
+		return next(c)
+	}
+}
+
+// RequirePermission lets through users whose role grants perm.
+func (a Authorizer) RequirePermission(perm authz.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			app := contexts.ExtractApp(c.Request().Context())
+			if !app.IsAuthenticated {
+				return loginRequired(c)
+			}
+			if !app.Can(perm) {
+				return accessDenied(c, a.Forbidden)
+			}
+
 			return next(c)
 		}
+	}
+}
+
+// loginRequired sends a visitor to the login page, which brings them back
+// afterwards. htmx gets a 401 telling it which page to load, JSON clients a
+// 401 error envelope.
+func loginRequired(c echo.Context) error {
+	loginURL := controllers.LoginURL(controllers.ReturnPath(c))
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		c.Response().Header().Set("HX-Redirect", loginURL)
+		return c.NoContent(http.StatusUnauthorized)
+	}
+	if controllers.WantsStatus(c) {
+		return controllers.JSONError(c, http.StatusUnauthorized, "Inicia sesión para continuar.")
+	}
+
+	return c.Redirect(http.StatusSeeOther, loginURL)
+}
+
+// accessDenied answers a logged-in user who lacks the rights for a page.
+func accessDenied(c echo.Context, forbidden echo.HandlerFunc) error {
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return c.NoContent(http.StatusForbidden)
+	}
+	if controllers.WantsStatus(c) {
+		return controllers.JSONError(c, http.StatusForbidden, "No tienes permiso para realizar esta acción.")
+	}
+
+	return forbidden(c)
+}
+
+// RedirectExpiredSession sends users whose backend session could not be
+// renewed back to the login page. The session is already cleared by then.
+func RedirectExpiredSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		err := next(c)
+		if errors.Is(err, api.ErrSessionExpired) {
+			return loginRequired(c)
+		}
 
-		return c.Redirect(http.StatusTemporaryRedirect, "/login")
+		return err
 	}
 }
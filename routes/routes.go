@@ -1,50 +1,75 @@
 package routes
 
 import (
-	"crypto/sha256"
+	"context"
 	"crypto/tls"
-	"encoding/base64"
-	"fmt"
+	"log/slog"
 	"net/http"
-	"strings"
+	"strconv"
 
 	"github.com/gorilla/csrf"
 	"github.com/gorilla/sessions"
 	"github.com/labstack/echo-contrib/session"
 	"github.com/labstack/echo/v4"
+	echomw "github.com/labstack/echo/v4/middleware"
 	"github.com/tikimcrzx723/alejandrinasweb/controllers"
-	"github.com/tikimcrzx723/alejandrinasweb/internal/env"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/authz"
+	"github.com/tikimcrzx723/alejandrinasweb/internal/config"
+	"github.com/tikimcrzx723/alejandrinasweb/routes/contexts"
 	"github.com/tikimcrzx723/alejandrinasweb/routes/middleware"
 	"github.com/tikimcrzx723/alejandrinasweb/static"
 )
 
-type Routes struct {
-	e *echo.Echo
-}
-
-func sessionKeyFromEnv(raw string) []byte {
-	decoded, err := base64.StdEncoding.DecodeString(raw)
-	if err == nil && len(decoded) >= 32 {
-		return decoded
-	}
+const (
+	csrfCookieName = "_gorilla_csrf"
+	// csrfMaxAge is how long, in seconds, a CSRF token cookie lives. A
+	// retired CSRF key can be dropped once this much time has passed.
+	csrfMaxAge = 12 * 60 * 60
+)
 
-	// Normalize arbitrary strings into a 32-byte key using SHA256.
-	hash := sha256.Sum256([]byte(raw))
-	return hash[:]
+type Routes struct {
+	e    *echo.Echo
+	ctrl controllers.Controller
+	cfg  config.Config
 }
 
-func NewRoutes() Routes {
+func NewRoutes(ctrl controllers.Controller, cfg config.Config, store sessions.Store) Routes {
 	e := echo.New()
+	e.HTTPErrorHandler = ctrl.HTTPErrorHandler
 
-	authKey := sessionKeyFromEnv(env.GetString("SESSION_AUTH_KEY", "zRJdixjhVNDh..."))
-	encKey := sessionKeyFromEnv(env.GetString("SESSION_ENC_KEY", "zRJdixjhVNDh..."))
+	e.Pre(echomw.RequestIDWithConfig(echomw.RequestIDConfig{
+		RequestIDHandler: func(c echo.Context, id string) {
+			ctx := context.WithValue(c.Request().Context(), contexts.RequestIDKey{}, id)
+			c.SetRequest(c.Request().WithContext(ctx))
+		},
+	}))
 
 	e.Use(
-		session.Middleware(sessions.NewCookieStore(authKey, encKey)),
-		controllers.RegisterAppContext,
+		echomw.RecoverWithConfig(echomw.RecoverConfig{
+			// The panic is returned as an error so HTTPErrorHandler answers
+			// it once the middleware chain unwinds.
+			DisableErrorHandler: true,
+			LogErrorFunc: func(c echo.Context, err error, stack []byte) error {
+				ctx := c.Request().Context()
+				slog.ErrorContext(ctx, "panic recovered",
+					"err", err,
+					"request_id", contexts.ExtractRequestID(ctx),
+					"stack", string(stack),
+				)
+				return err
+			},
+		}),
+		session.Middleware(store),
+		ctrl.RegisterAppContext,
 		controllers.RegisterFlashMessageContext,
+		middleware.RedirectExpiredSession,
 	)
 
+	// HTML forms can only POST; a hidden _method field selects PATCH/DELETE.
+	e.Pre(echomw.MethodOverrideWithConfig(echomw.MethodOverrideConfig{
+		Getter: echomw.MethodFromForm("_method"),
+	}))
+
 	e.Pre(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			if c.Request().Header.Get("X-Forwarded-Proto") == "https" {
@@ -57,7 +82,7 @@ func NewRoutes() Routes {
 		}
 	})
 
-	csrfSecure := env.GetBool("CSRF_COOKIE_SECURE", false)
+	csrfSecure := cfg.CSRF.CookieSecure
 	sameSiteMode := csrf.SameSiteDefaultMode
 	if csrfSecure {
 		sameSiteMode = csrf.SameSiteNoneMode
@@ -75,101 +100,179 @@ func NewRoutes() Routes {
 		})
 	}
 
-	csrfKey := env.GetString("CSRF_TOKEN_KEY", "32-byte-secret-key-minimo-32-chars!!")
-	trustedOriginsValue := env.GetString("CSRF_TRUSTED_ORIGINS", "")
-	var trustedOrigins []string
-	for origin := range strings.SplitSeq(trustedOriginsValue, ",") {
-		origin = strings.TrimSpace(origin)
-		if origin != "" {
-			trustedOrigins = append(trustedOrigins, origin)
-		}
+	csrfCookie := http.Cookie{
+		Name:     csrfCookieName,
+		Path:     "/",
+		MaxAge:   csrfMaxAge,
+		Secure:   csrfSecure,
+		HttpOnly: true,
+		SameSite: http.SameSite(sameSiteMode),
 	}
 
 	csrfOptions := []csrf.Option{
-		csrf.Secure(csrfSecure),
-		csrf.Path("/"),
+		csrf.CookieName(csrfCookie.Name),
+		csrf.MaxAge(csrfCookie.MaxAge),
+		csrf.Secure(csrfCookie.Secure),
+		csrf.HttpOnly(csrfCookie.HttpOnly),
+		csrf.Path(csrfCookie.Path),
 		csrf.SameSite(sameSiteMode),
-		csrf.ErrorHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-
-			fmt.Println("\n================ CSRF ERROR ================")
-			fmt.Println("Method:", r.Method)
-			fmt.Println("Path:", r.URL.Path)
-
-			// 1) Revisar cookie CSRF
-			c, err := r.Cookie("_gorilla_csrf") // <- nombre real de la cookie
-			if err != nil {
-				fmt.Println("Cookie _gorilla_csrf NO presente:", err)
-			} else {
-				fmt.Println("Cookie _gorilla_csrf =", c.Value)
-			}
-
-			// 2) Revisar header
-			fmt.Println("Header X-CSRF-Token =", r.Header.Get("X-CSRF-Token"))
-
-			// 3) Revisar formulario
-			_ = r.ParseForm()
-			fmt.Println("Form gorilla.csrf.Token =", r.Form.Get("gorilla.csrf.Token"))
-			fmt.Println("Failure Reason:", csrf.FailureReason(r))
-
-			fmt.Println("============================================")
-
-			w.WriteHeader(http.StatusForbidden)
-			_, _ = w.Write([]byte("csrf failed"))
-		})),
+		csrf.ErrorHandler(http.HandlerFunc(controllers.CSRFFailed)),
 	}
 
-	if len(trustedOrigins) > 0 {
-		csrfOptions = append(csrfOptions, csrf.TrustedOrigins(trustedOrigins))
+	if len(cfg.CSRF.TrustedOrigins) > 0 {
+		csrfOptions = append(csrfOptions, csrf.TrustedOrigins(cfg.CSRF.TrustedOrigins))
 	}
 
-	csrfMiddleware := csrf.Protect([]byte(csrfKey), csrfOptions...)
+	csrfMiddleware := csrf.Protect(cfg.CSRF.Key, csrfOptions...)
 
-	e.Use(echo.WrapMiddleware(csrfMiddleware))
+	e.Use(
+		middleware.RotateCSRFKey(cfg.CSRF.Key, cfg.CSRF.PreviousKeys, csrfCookie),
+		echo.WrapMiddleware(csrfMiddleware),
+	)
 
 	echo.MustSubFS(static.Files, "static")
 	e.StaticFS("/static", static.Files)
-	return Routes{e}
+	return Routes{e, ctrl, cfg}
 }
 
 func (r Routes) Load() *echo.Echo {
-	adminRoutes := r.e.Group("/admin", middleware.RequireAdminRole)
+	guard := middleware.Authorizer{Forbidden: r.ctrl.Forbidden}
+	adminRoutes := r.e.Group("/admin", guard.RequireStaff)
 	adminRoutes.GET("/dashboard/product/register", func(c echo.Context) error {
-		return controllers.RegisterProductPage(c)
-	})
+		return r.ctrl.RegisterProductPage(c)
+	}, guard.RequirePermission(authz.ProductsRead))
 	adminRoutes.GET("/dashboard/category/register", func(c echo.Context) error {
-		return controllers.CategoryPage(c)
-	})
+		return r.ctrl.CategoryPage(c)
+	}, guard.RequirePermission(authz.CategoriesRead))
 
 	adminRoutes.POST("/category/register", func(c echo.Context) error {
-		return controllers.CreateCategory(c)
-	})
+		return r.ctrl.CreateCategory(c)
+	}, guard.RequirePermission(authz.CategoriesWrite))
+	uploadLimit := echomw.BodyLimit(strconv.FormatInt(r.cfg.Upload.MaxSize, 10))
 	adminRoutes.POST("/product/register", func(c echo.Context) error {
-		return controllers.CreateProduct(c)
-	})
+		return r.ctrl.CreateProduct(c)
+	}, guard.RequirePermission(authz.ProductsWrite), uploadLimit)
 	adminRoutes.POST("/product/update", func(c echo.Context) error {
-		return controllers.UpdateProduct(c)
+		return r.ctrl.UpdateProduct(c)
+	}, guard.RequirePermission(authz.ProductsWrite), uploadLimit)
+	adminRoutes.GET("/dashboard/orders", func(c echo.Context) error {
+		return r.ctrl.AdminOrdersPage(c)
+	}, guard.RequirePermission(authz.OrdersRead))
+	adminRoutes.GET("/dashboard/orders/:id", func(c echo.Context) error {
+		return r.ctrl.AdminOrderPage(c)
+	}, guard.RequirePermission(authz.OrdersRead))
+	adminRoutes.POST("/orders/:id/status", func(c echo.Context) error {
+		return r.ctrl.UpdateOrderStatus(c)
+	}, guard.RequirePermission(authz.OrdersWrite))
+	checkoutRoutes := r.e.Group("/checkout", middleware.RequireAuth)
+	checkoutRoutes.GET("", func(c echo.Context) error {
+		return r.ctrl.Checkout(c)
+	})
+	checkoutRoutes.GET("/address", func(c echo.Context) error {
+		return r.ctrl.CheckoutAddressPage(c)
+	})
+	checkoutRoutes.POST("/address", func(c echo.Context) error {
+		return r.ctrl.SaveCheckoutAddress(c)
+	})
+	checkoutRoutes.GET("/shipping", func(c echo.Context) error {
+		return r.ctrl.CheckoutShippingPage(c)
+	})
+	checkoutRoutes.POST("/shipping", func(c echo.Context) error {
+		return r.ctrl.SaveCheckoutShipping(c)
+	})
+	checkoutRoutes.GET("/review", func(c echo.Context) error {
+		return r.ctrl.CheckoutReviewPage(c)
 	})
+	checkoutRoutes.POST("/confirm", func(c echo.Context) error {
+		return r.ctrl.ConfirmOrder(c)
+	})
+	checkoutRoutes.GET("/confirmation/:id", func(c echo.Context) error {
+		return r.ctrl.OrderConfirmationPage(c)
+	})
+
+	accountRoutes := r.e.Group("/account", middleware.RequireAuth)
+	accountRoutes.GET("/wishlist", func(c echo.Context) error {
+		return r.ctrl.WishlistPage(c)
+	})
+	accountRoutes.GET("/orders", func(c echo.Context) error {
+		return r.ctrl.AccountOrdersPage(c)
+	})
+	accountRoutes.GET("/orders/:id", func(c echo.Context) error {
+		return r.ctrl.AccountOrderPage(c)
+	})
+	accountRoutes.GET("/sessions", func(c echo.Context) error {
+		return r.ctrl.AccountSessionsPage(c)
+	})
+	accountRoutes.DELETE("/sessions", func(c echo.Context) error {
+		return r.ctrl.RevokeOtherSessions(c)
+	})
+	accountRoutes.DELETE("/sessions/:id", func(c echo.Context) error {
+		return r.ctrl.RevokeSession(c)
+	})
+
 	// setup routes for diferents pages
 	r.e.GET("", func(c echo.Context) error {
-		return controllers.Home(c)
+		return r.ctrl.Home(c)
 	})
 	r.e.GET("/product/:sku", func(c echo.Context) error {
-		return controllers.Product(c)
+		return r.ctrl.Product(c)
+	})
+	r.e.GET("/categoria/:slug", func(c echo.Context) error {
+		return r.ctrl.CategoryProductsPage(c)
+	})
+	r.e.GET("/search", func(c echo.Context) error {
+		return r.ctrl.Search(c)
+	})
+	r.e.GET("/cart", func(c echo.Context) error {
+		return r.ctrl.CartPage(c)
+	})
+	r.e.POST("/cart/items", func(c echo.Context) error {
+		return r.ctrl.AddCartItem(c)
+	})
+	r.e.PATCH("/cart/items/:sku", func(c echo.Context) error {
+		return r.ctrl.UpdateCartItem(c)
+	})
+	r.e.DELETE("/cart/items/:sku", func(c echo.Context) error {
+		return r.ctrl.RemoveCartItem(c)
+	})
+	r.e.POST("/wishlist/:sku", func(c echo.Context) error {
+		return r.ctrl.ToggleWishlistItem(c)
 	})
 	r.e.POST("/register", func(c echo.Context) error {
-		return controllers.CreateUser(c)
+		return r.ctrl.CreateUser(c)
+	})
+	r.e.GET("/forgot-password", func(c echo.Context) error {
+		return r.ctrl.ForgotPasswordPage(c)
+	})
+	r.e.POST("/forgot-password", func(c echo.Context) error {
+		return r.ctrl.SendPasswordReset(c)
+	})
+	r.e.GET("/reset-password", func(c echo.Context) error {
+		return r.ctrl.ResetPasswordPage(c)
+	})
+	r.e.POST("/reset-password", func(c echo.Context) error {
+		return r.ctrl.ResetPassword(c)
+	})
+	r.e.GET("/activate", func(c echo.Context) error {
+		return r.ctrl.ActivateUser(c)
+	})
+	r.e.GET("/activate/resend", func(c echo.Context) error {
+		return r.ctrl.ResendActivationPage(c)
+	})
+	r.e.POST("/activate/resend", func(c echo.Context) error {
+		return r.ctrl.ResendActivation(c)
 	})
 	r.e.GET("/login", func(c echo.Context) error {
-		return controllers.LoginPage(c)
+		return r.ctrl.LoginPage(c)
 	}, middleware.RequireNoAuth)
 	r.e.POST("/login", func(c echo.Context) error {
-		return controllers.LoginUser(c)
+		return r.ctrl.LoginUser(c)
 	})
 	r.e.GET("/logout", func(c echo.Context) error {
 		return controllers.LogoutUser(c)
 	}, middleware.RequireAuth)
 	r.e.GET("/register", func(c echo.Context) error {
-		return controllers.Register(c)
+		return r.ctrl.Register(c)
 	}, middleware.RequireNoAuth)
 	return r.e
 }
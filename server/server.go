@@ -1,6 +1,8 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -9,26 +11,140 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	defaultReadTimeout       = 30 * time.Second
+	defaultReadHeaderTimeout = 5 * time.Second
+	defaultWriteTimeout      = 30 * time.Second
+	defaultIdleTimeout       = 2 * time.Minute
+	defaultShutdownTimeout   = 15 * time.Second
+)
+
 type Server struct {
 	host   string
 	port   int32
 	routes *echo.Echo
+
+	readTimeout       time.Duration
+	readHeaderTimeout time.Duration
+	writeTimeout      time.Duration
+	idleTimeout       time.Duration
+	shutdownTimeout   time.Duration
+	hooks             []shutdownHook
+}
+
+type shutdownHook struct {
+	name string
+	fn   func(context.Context) error
 }
 
-func NewServer(host string, port int32, routes *echo.Echo) Server {
-	return Server{host, port, routes}
+type Option func(*Server)
+
+// WithReadTimeout bounds reading a whole request, body included, so it must
+// leave room for image uploads.
+func WithReadTimeout(d time.Duration) Option {
+	return func(s *Server) {
+		s.readTimeout = d
+	}
 }
 
-func (s Server) Start() {
-	srv := http.Server{
-		Addr:         fmt.Sprintf("%v:%v", s.host, s.port),
-		Handler:      s.routes,
-		ReadTimeout:  time.Second,
-		WriteTimeout: 10 * time.Second,
+func WithReadHeaderTimeout(d time.Duration) Option {
+	return func(s *Server) {
+		s.readHeaderTimeout = d
 	}
+}
 
-	slog.Info("starting the server", "host", s.host, "port", s.port)
-	if err := srv.ListenAndServe(); err != nil {
-		panic(err)
+func WithWriteTimeout(d time.Duration) Option {
+	return func(s *Server) {
+		s.writeTimeout = d
 	}
 }
+
+func WithIdleTimeout(d time.Duration) Option {
+	return func(s *Server) {
+		s.idleTimeout = d
+	}
+}
+
+// WithShutdownTimeout sets how long Start waits for in-flight requests and
+// shutdown hooks once it is asked to stop.
+func WithShutdownTimeout(d time.Duration) Option {
+	return func(s *Server) {
+		s.shutdownTimeout = d
+	}
+}
+
+// OnShutdown registers fn to run after the server has stopped taking
+// requests. Hooks run in registration order and share the shutdown deadline.
+func OnShutdown(name string, fn func(context.Context) error) Option {
+	return func(s *Server) {
+		s.hooks = append(s.hooks, shutdownHook{name: name, fn: fn})
+	}
+}
+
+func NewServer(host string, port int32, routes *echo.Echo, opts ...Option) Server {
+	s := Server{
+		host:              host,
+		port:              port,
+		routes:            routes,
+		readTimeout:       defaultReadTimeout,
+		readHeaderTimeout: defaultReadHeaderTimeout,
+		writeTimeout:      defaultWriteTimeout,
+		idleTimeout:       defaultIdleTimeout,
+		shutdownTimeout:   defaultShutdownTimeout,
+	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	return s
+}
+
+// Start serves until ctx is cancelled, then drains in-flight requests and
+// runs the shutdown hooks. It returns nil after a clean shutdown.
+func (s Server) Start(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              fmt.Sprintf("%v:%v", s.host, s.port),
+		Handler:           s.routes,
+		ReadTimeout:       s.readTimeout,
+		ReadHeaderTimeout: s.readHeaderTimeout,
+		WriteTimeout:      s.writeTimeout,
+		IdleTimeout:       s.idleTimeout,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("starting the server", "host", s.host, "port", s.port)
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("listen and serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down the server", "timeout", s.shutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		errs = append(errs, fmt.Errorf("shutdown: %w", err))
+	}
+	if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		errs = append(errs, fmt.Errorf("listen and serve: %w", err))
+	}
+
+	for _, hook := range s.hooks {
+		if err := hook.fn(shutdownCtx); err != nil {
+			errs = append(errs, fmt.Errorf("shutdown hook %s: %w", hook.name, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	slog.Info("server stopped")
+	return nil
+}
@@ -8,9 +8,32 @@ package views
 import "github.com/a-h/templ"
 import templruntime "github.com/a-h/templ/runtime"
 
+import "context"
+import "github.com/tikimcrzx723/alejandrinasweb/internal/authz"
 import "github.com/tikimcrzx723/alejandrinasweb/routes/contexts"
 import "time"
 
+// adminSections are the dashboard pages in menu order, with the permission
+// each one needs.
+var adminSections = []struct {
+	perm authz.Permission
+	path string
+}{
+	{authz.ProductsRead, "/admin/dashboard/product/register"},
+	{authz.CategoriesRead, "/admin/dashboard/category/register"},
+	{authz.OrdersRead, "/admin/dashboard/orders"},
+}
+
+// adminHome returns the first dashboard page the user of ctx can open.
+func adminHome(ctx context.Context) string {
+	for _, section := range adminSections {
+		if contexts.Can(ctx, section.perm) {
+			return section.path
+		}
+	}
+	return "/"
+}
+
 func adminNav() templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
@@ -39,7 +62,7 @@ func adminNav() templ.Component {
 		var templ_7745c5c3_Var2 string
 		templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs(contexts.ExtractApp(ctx).Role)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/adminBase.templ`, Line: 106, Col: 91}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/adminBase.templ`, Line: 129, Col: 91}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var2))
 		if templ_7745c5c3_Err != nil {
@@ -74,33 +97,68 @@ func adminSideNav() templ.Component {
 			templ_7745c5c3_Var3 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "<!-- Sidebar --><aside class=\"admin-sidebar\" id=\"admin-sidebar\"><div class=\"sidebar-content\"><nav class=\"sidebar-nav\"><ul class=\"nav flex-column\"><li class=\"nav-item\"><a class=\"nav-link\" href=\"")
-		if templ_7745c5c3_Err != nil {
-			return templ_7745c5c3_Err
-		}
-		var templ_7745c5c3_Var4 templ.SafeURL
-		templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinURLErrs(templ.SafeURL("/admin/dashboard/product/register"))
-		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/adminBase.templ`, Line: 129, Col: 100}
-		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
-		if templ_7745c5c3_Err != nil {
-			return templ_7745c5c3_Err
-		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "\"><i class=\"bi bi-box\"></i> <span>Productos</span></a></li><li class=\"nav-item\"><a class=\"nav-link\" href=\"")
-		if templ_7745c5c3_Err != nil {
-			return templ_7745c5c3_Err
-		}
-		var templ_7745c5c3_Var5 templ.SafeURL
-		templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinURLErrs(templ.SafeURL("/admin/dashboard/category/register"))
-		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/adminBase.templ`, Line: 135, Col: 101}
-		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "<!-- Sidebar --><aside class=\"admin-sidebar\" id=\"admin-sidebar\"><div class=\"sidebar-content\"><nav class=\"sidebar-nav\"><ul class=\"nav flex-column\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "\"><i class=\"bi bi-box\"></i> <span>Categorias</span></a></li></ul></nav></div></aside><!-- Floating Hamburger Menu --><button class=\"hamburger-menu\" type=\"button\" data-sidebar-toggle aria-label=\"Toggle sidebar\"><i class=\"bi bi-list\"></i></button>")
+		if contexts.Can(ctx, authz.ProductsRead) {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "<li class=\"nav-item\"><a class=\"nav-link\" href=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var4 templ.SafeURL
+			templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinURLErrs(templ.SafeURL("/admin/dashboard/product/register"))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/adminBase.templ`, Line: 153, Col: 104}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "\"><i class=\"bi bi-box\"></i> <span>Productos</span></a></li>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		if contexts.Can(ctx, authz.CategoriesRead) {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "<li class=\"nav-item\"><a class=\"nav-link\" href=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var5 templ.SafeURL
+			templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinURLErrs(templ.SafeURL("/admin/dashboard/category/register"))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/adminBase.templ`, Line: 161, Col: 105}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "\"><i class=\"bi bi-box\"></i> <span>Categorias</span></a></li>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		if contexts.Can(ctx, authz.OrdersRead) {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "<li class=\"nav-item\"><a class=\"nav-link\" href=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var6 templ.SafeURL
+			templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinURLErrs(templ.SafeURL("/admin/dashboard/orders"))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/adminBase.templ`, Line: 169, Col: 94}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "\"><i class=\"bi bi-receipt\"></i> <span>Órdenes</span></a></li>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "</ul></nav></div></aside><!-- Floating Hamburger Menu --><button class=\"hamburger-menu\" type=\"button\" data-sidebar-toggle aria-label=\"Toggle sidebar\"><i class=\"bi bi-list\"></i></button>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -124,25 +182,25 @@ func adminBaseLayout(title string) templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var6 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var6 == nil {
-			templ_7745c5c3_Var6 = templ.NopComponent
+		templ_7745c5c3_Var7 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var7 == nil {
+			templ_7745c5c3_Var7 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "<!doctype html><html lang=\"en\" data-bs-theme=\"light\"><head><!-- Meta Tags --><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\"><meta name=\"description\" content=\"Modern Bootstrap 5 Admin Template - Clean, responsive dashboard\"><meta name=\"keywords\" content=\"bootstrap, admin, dashboard, template, modern, responsive\"><meta name=\"author\" content=\"Bootstrap Admin Template\"><!-- Open Graph Meta Tags --><meta property=\"og:title\" content=\"Modern Bootstrap Admin Template\"><meta property=\"og:description\" content=\"Clean and modern admin dashboard template built with Bootstrap 5\"><meta property=\"og:type\" content=\"website\"><!-- Favicon --><link rel=\"icon\" type=\"image/svg+xml\" href=\"/static/admin/assets/favicon-CvUZKS4z.svg\"><link rel=\"icon\" type=\"image/png\" href=\"/static/admin/assets/favicon-B_cwPWBd.png\"><!-- Preconnect to external domains --><link rel=\"preconnect\" href=\"https://fonts.googleapis.com\"><link rel=\"preconnect\" href=\"https://fonts.gstatic.com\" crossorigin><!-- Fonts --><link href=\"https://fonts.googleapis.com/css2?family=Inter:wght@300;400;500;600;700&display=swap\" rel=\"stylesheet\"><!-- Title --><title>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, "<!doctype html><html lang=\"en\" data-bs-theme=\"light\"><head><!-- Meta Tags --><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\"><meta name=\"description\" content=\"Modern Bootstrap 5 Admin Template - Clean, responsive dashboard\"><meta name=\"keywords\" content=\"bootstrap, admin, dashboard, template, modern, responsive\"><meta name=\"author\" content=\"Bootstrap Admin Template\"><!-- Open Graph Meta Tags --><meta property=\"og:title\" content=\"Modern Bootstrap Admin Template\"><meta property=\"og:description\" content=\"Clean and modern admin dashboard template built with Bootstrap 5\"><meta property=\"og:type\" content=\"website\"><!-- Favicon --><link rel=\"icon\" type=\"image/svg+xml\" href=\"/static/admin/assets/favicon-CvUZKS4z.svg\"><link rel=\"icon\" type=\"image/png\" href=\"/static/admin/assets/favicon-B_cwPWBd.png\"><!-- Preconnect to external domains --><link rel=\"preconnect\" href=\"https://fonts.googleapis.com\"><link rel=\"preconnect\" href=\"https://fonts.gstatic.com\" crossorigin><!-- Fonts --><link href=\"https://fonts.googleapis.com/css2?family=Inter:wght@300;400;500;600;700&display=swap\" rel=\"stylesheet\"><!-- Title --><title>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var7 string
-		templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(title)
+		var templ_7745c5c3_Var8 string
+		templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(title)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/adminBase.templ`, Line: 182, Col: 21}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/adminBase.templ`, Line: 217, Col: 21}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "</title><!-- Theme Color --><meta name=\"theme-color\" content=\"#6366f1\"><!-- PWA Manifest --><link rel=\"manifest\" href=\"/static/admin/assets/manifest-DTaoG9pG.json\"><script type=\"module\" crossorigin src=\"/static/admin/assets/vendor-bootstrap-C9iorZI5.js\"></script><script type=\"module\" crossorigin src=\"/static/admin/assets/vendor-charts-DGwYAWel.js\"></script><script type=\"module\" crossorigin src=\"/static/admin/assets/vendor-ui-D52CawDg.js\"></script><script type=\"module\" crossorigin src=\"/static/admin/assets/main-vE65Hd7W.js\"></script><link rel=\"stylesheet\" crossorigin href=\"/static/admin/assets/main-QD_VOj1Y.css\"><link rel=\"stylesheet\" crossorigin href=\"/static/css/upload-image.css\"></head><body data-page=\"dashboard\" class=\"admin-layout\"><!-- Loading Screen --><div id=\"loading-screen\" class=\"loading-screen\"><div class=\"loading-spinner\"><div class=\"spinner-border text-primary\" role=\"status\"><span class=\"visually-hidden\">Loading...</span></div></div></div><!-- Main Wrapper --><div class=\"admin-wrapper\" id=\"admin-wrapper\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, "</title><!-- Theme Color --><meta name=\"theme-color\" content=\"#6366f1\"><!-- PWA Manifest --><link rel=\"manifest\" href=\"/static/admin/assets/manifest-DTaoG9pG.json\"><script type=\"module\" crossorigin src=\"/static/admin/assets/vendor-bootstrap-C9iorZI5.js\"></script><script type=\"module\" crossorigin src=\"/static/admin/assets/vendor-charts-DGwYAWel.js\"></script><script type=\"module\" crossorigin src=\"/static/admin/assets/vendor-ui-D52CawDg.js\"></script><script type=\"module\" crossorigin src=\"/static/admin/assets/main-vE65Hd7W.js\"></script><link rel=\"stylesheet\" crossorigin href=\"/static/admin/assets/main-QD_VOj1Y.css\"><link rel=\"stylesheet\" crossorigin href=\"/static/css/upload-image.css\"></head><body data-page=\"dashboard\" class=\"admin-layout\"><!-- Loading Screen --><div id=\"loading-screen\" class=\"loading-screen\"><div class=\"loading-spinner\"><div class=\"spinner-border text-primary\" role=\"status\"><span class=\"visually-hidden\">Loading...</span></div></div></div><!-- Main Wrapper --><div class=\"admin-wrapper\" id=\"admin-wrapper\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -154,28 +212,80 @@ func adminBaseLayout(title string) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "<!-- Main Content --><main class=\"admin-main\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 13, "<!-- Main Content --><main class=\"admin-main\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templ_7745c5c3_Var6.Render(ctx, templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = templ_7745c5c3_Var7.Render(ctx, templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "</main><!-- Footer --><footer class=\"admin-footer\"><div class=\"container-fluid\"><div class=\"row\"><div class=\"col-md-6\"><p class=\"mb-0 text-muted\">© ")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, "</main><!-- Footer --><footer class=\"admin-footer\"><div class=\"container-fluid\"><div class=\"row\"><div class=\"col-md-6\"><p class=\"mb-0 text-muted\">© ")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var8 string
-		templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(time.Now().Year())
+		var templ_7745c5c3_Var9 string
+		templ_7745c5c3_Var9, templ_7745c5c3_Err = templ.JoinStringErrs(time.Now().Year())
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/adminBase.templ`, Line: 225, Col: 76}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/adminBase.templ`, Line: 260, Col: 76}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var9))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 15, "</p></div><div class=\"col-md-6 text-md-end\"><p class=\"mb-0 text-muted\">Alejandrinas Web</p></div></div></div></footer></div><!-- Toast Container --><div aria-live=\"polite\" aria-atomic=\"true\" class=\"position-fixed top-0 end-0 p-3\" style=\"z-index: 11\"><div id=\"toast-container\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = flashToasts().Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 16, "</div></div><!-- Icon Demo Modal --><div class=\"modal fade\" id=\"iconDemoModal\" tabindex=\"-1\"><div class=\"modal-dialog modal-lg\"><div class=\"modal-content\"><div class=\"modal-header\"><h5 class=\"modal-title\"><i class=\"bi bi-palette me-2\"></i> Icon System Demo</h5><button type=\"button\" class=\"btn-close\" data-bs-dismiss=\"modal\"></button></div><div class=\"modal-body\" x-data=\"iconDemo\"><div class=\"row mb-4\"><div class=\"col-md-6\"><h6>Current Provider: <span class=\"badge bg-primary\" x-text=\"currentProvider\"></span></h6><div class=\"btn-group\" role=\"group\"><button type=\"button\" class=\"btn btn-outline-primary\" @click=\"switchProvider('bootstrap')\" :class=\"{ 'active': currentProvider === 'bootstrap' }\">Bootstrap Icons</button> <button type=\"button\" class=\"btn btn-outline-primary\" @click=\"switchProvider('lucide')\" :class=\"{ 'active': currentProvider === 'lucide' }\">Lucide Icons</button></div></div></div><div class=\"row g-3\"><div class=\"col-md-3 text-center\"><div class=\"p-3 border rounded\"><i class=\"bi bi-speedometer2 icon-xl text-primary mb-2\"></i><br><small>Dashboard</small></div></div><div class=\"col-md-3 text-center\"><div class=\"p-3 border rounded\"><i class=\"bi bi-people icon-xl text-success mb-2\"></i><br><small>Users</small></div></div><div class=\"col-md-3 text-center\"><div class=\"p-3 border rounded\"><i class=\"bi bi-graph-up icon-xl text-info mb-2\"></i><br><small>Analytics</small></div></div><div class=\"col-md-3 text-center\"><div class=\"p-3 border rounded\"><i class=\"bi bi-gear icon-xl text-warning mb-2\"></i><br><small>Settings</small></div></div></div><h6 class=\"mt-4\">Icon Animations</h6><div class=\"row g-3\"><div class=\"col-md-3 text-center\"><i class=\"bi bi-arrow-clockwise icon-xl icon-spin text-primary\"></i><br><small>Spin</small></div><div class=\"col-md-3 text-center\"><i class=\"bi bi-heart icon-xl icon-pulse text-danger\"></i><br><small>Pulse</small></div><div class=\"col-md-3 text-center\"><i class=\"bi bi-star icon-xl icon-hover text-warning\"></i><br><small>Hover Effect</small></div><div class=\"col-md-3 text-center\"><i class=\"bi bi-check-circle icon-xl text-success\"></i><br><small>Static</small></div></div></div><div class=\"modal-footer\"><button type=\"button\" class=\"btn btn-secondary\" data-bs-dismiss=\"modal\"><i class=\"bi bi-x me-2\"></i>Close</button></div></div></div></div><!-- Scripts --><script>\n        document.addEventListener('DOMContentLoaded', () => {\n            const toggleButton = document.querySelector('[data-sidebar-toggle]');\n            const wrapper = document.getElementById('admin-wrapper');\n\n            if (toggleButton && wrapper) {\n            // Set initial state from localStorage\n            const isCollapsed = localStorage.getItem('sidebar-collapsed') === 'true';\n            if (isCollapsed) {\n                wrapper.classList.add('sidebar-collapsed');\n                toggleButton.classList.add('is-active');\n            }\n\n            // Attach click listener\n            toggleButton.addEventListener('click', () => {\n                const isCurrentlyCollapsed = wrapper.classList.contains('sidebar-collapsed');\n                \n                if (isCurrentlyCollapsed) {\n                wrapper.classList.remove('sidebar-collapsed');\n                toggleButton.classList.remove('is-active');\n                localStorage.setItem('sidebar-collapsed', 'false');\n                } else {\n                wrapper.classList.add('sidebar-collapsed');\n                toggleButton.classList.add('is-active');\n                localStorage.setItem('sidebar-collapsed', 'true');\n                }\n            });\n            }\n        });\n        </script><!-- New Item Modal --><div class=\"modal fade\" id=\"newItemModal\" tabindex=\"-1\" aria-labelledby=\"newItemModalLabel\" aria-hidden=\"true\"><div class=\"modal-dialog modal-dialog-centered\"><div class=\"modal-content\"><div class=\"modal-header border-0 pb-0\"><h5 class=\"modal-title\" id=\"newItemModalLabel\"><i class=\"bi bi-plus-circle text-primary me-2\"></i> Quick Add</h5><button type=\"button\" class=\"btn-close\" data-bs-dismiss=\"modal\" aria-label=\"Close\"></button></div><div class=\"modal-body\" x-data=\"quickAddForm()\"><p class=\"text-muted small mb-4\">Create a new item quickly from the dashboard.</p><!-- Item Type Selection --><div class=\"mb-4\"><label class=\"form-label fw-semibold\">What would you like to add?</label><div class=\"btn-group w-100\" role=\"group\"><button type=\"button\" class=\"btn btn-outline-primary btn-sm\" :class=\"{ 'active': itemType === 'task' }\" @click=\"itemType = 'task'\"><i class=\"bi bi-check2-square\"></i> Task</button> <button type=\"button\" class=\"btn btn-outline-success btn-sm\" :class=\"{ 'active': itemType === 'note' }\" @click=\"itemType = 'note'\"><i class=\"bi bi-sticky\"></i> Note</button> <button type=\"button\" class=\"btn btn-outline-info btn-sm\" :class=\"{ 'active': itemType === 'event' }\" @click=\"itemType = 'event'\"><i class=\"bi bi-calendar-event\"></i> Event</button> <button type=\"button\" class=\"btn btn-outline-warning btn-sm\" :class=\"{ 'active': itemType === 'reminder' }\" @click=\"itemType = 'reminder'\"><i class=\"bi bi-bell\"></i> Reminder</button></div></div><!-- Title --><div class=\"mb-3\"><label for=\"itemTitle\" class=\"form-label fw-semibold\">Title</label> <input type=\"text\" class=\"form-control\" id=\"itemTitle\" x-model=\"title\" placeholder=\"Enter a title...\" autofocus></div><!-- Description --><div class=\"mb-3\"><label for=\"itemDescription\" class=\"form-label fw-semibold\">Description</label> <textarea class=\"form-control\" id=\"itemDescription\" rows=\"3\" x-model=\"description\" placeholder=\"Add some details...\"></textarea></div><!-- Priority (shown for tasks) --><div class=\"mb-3\" x-show=\"itemType === 'task'\" x-transition><label class=\"form-label fw-semibold d-block\">Priority</label><div class=\"btn-group\" role=\"group\" aria-label=\"Priority selection\"><input type=\"radio\" class=\"btn-check\" name=\"priorityRadio\" id=\"priorityLow\" value=\"low\" x-model=\"priority\" autocomplete=\"off\"> <label class=\"btn btn-outline-success btn-sm\" for=\"priorityLow\"><i class=\"bi bi-flag\"></i> Low</label> <input type=\"radio\" class=\"btn-check\" name=\"priorityRadio\" id=\"priorityMedium\" value=\"medium\" x-model=\"priority\" autocomplete=\"off\"> <label class=\"btn btn-outline-warning btn-sm\" for=\"priorityMedium\"><i class=\"bi bi-flag-fill\"></i> Medium</label> <input type=\"radio\" class=\"btn-check\" name=\"priorityRadio\" id=\"priorityHigh\" value=\"high\" x-model=\"priority\" autocomplete=\"off\"> <label class=\"btn btn-outline-danger btn-sm\" for=\"priorityHigh\"><i class=\"bi bi-flag-fill\"></i> High</label></div></div><!-- Date (shown for events/reminders) --><div class=\"mb-3\" x-show=\"itemType === 'event' || itemType === 'reminder'\" x-transition><label for=\"itemDate\" class=\"form-label fw-semibold\">Date & Time</label> <input type=\"datetime-local\" class=\"form-control\" id=\"itemDate\" x-model=\"dateTime\"></div><!-- Assign to (shown for tasks) --><div class=\"mb-3\" x-show=\"itemType === 'task'\" x-transition><label for=\"assignTo\" class=\"form-label fw-semibold\">Assign to</label> <select class=\"form-select\" id=\"assignTo\" x-model=\"assignee\"><option value=\"\">Select team member...</option> <option value=\"john\">John Doe</option> <option value=\"jane\">Jane Smith</option> <option value=\"mike\">Mike Johnson</option> <option value=\"sarah\">Sarah Williams</option></select></div></div><div class=\"modal-footer border-0 pt-0\"><button type=\"button\" class=\"btn btn-light\" data-bs-dismiss=\"modal\">Cancel</button> <button type=\"button\" class=\"btn btn-primary\" @click=\"saveItem()\" data-bs-dismiss=\"modal\"><i class=\"bi bi-check-lg me-1\"></i> Create Item</button></div></div></div></div></body></html>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
+// reopenModal shows the modal with the given id once the page has loaded, so a
+// form rejected by the server is displayed again with its errors.
+func reopenModal(id string) templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var10 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var10 == nil {
+			templ_7745c5c3_Var10 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, "<button type=\"button\" class=\"d-none\" data-bs-toggle=\"modal\" data-bs-target=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var11 string
+		templ_7745c5c3_Var11, templ_7745c5c3_Err = templ.JoinStringErrs("#" + id)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/adminBase.templ`, Line: 504, Col: 89}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var11))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "</p></div><div class=\"col-md-6 text-md-end\"><p class=\"mb-0 text-muted\">Alejandrinas Web</p></div></div></div></footer></div><!-- Toast Container --><div aria-live=\"polite\" aria-atomic=\"true\" class=\"position-fixed top-0 end-0 p-3\" style=\"z-index: 11\"><div id=\"toast-container\"></div></div><!-- Icon Demo Modal --><div class=\"modal fade\" id=\"iconDemoModal\" tabindex=\"-1\"><div class=\"modal-dialog modal-lg\"><div class=\"modal-content\"><div class=\"modal-header\"><h5 class=\"modal-title\"><i class=\"bi bi-palette me-2\"></i> Icon System Demo</h5><button type=\"button\" class=\"btn-close\" data-bs-dismiss=\"modal\"></button></div><div class=\"modal-body\" x-data=\"iconDemo\"><div class=\"row mb-4\"><div class=\"col-md-6\"><h6>Current Provider: <span class=\"badge bg-primary\" x-text=\"currentProvider\"></span></h6><div class=\"btn-group\" role=\"group\"><button type=\"button\" class=\"btn btn-outline-primary\" @click=\"switchProvider('bootstrap')\" :class=\"{ 'active': currentProvider === 'bootstrap' }\">Bootstrap Icons</button> <button type=\"button\" class=\"btn btn-outline-primary\" @click=\"switchProvider('lucide')\" :class=\"{ 'active': currentProvider === 'lucide' }\">Lucide Icons</button></div></div></div><div class=\"row g-3\"><div class=\"col-md-3 text-center\"><div class=\"p-3 border rounded\"><i class=\"bi bi-speedometer2 icon-xl text-primary mb-2\"></i><br><small>Dashboard</small></div></div><div class=\"col-md-3 text-center\"><div class=\"p-3 border rounded\"><i class=\"bi bi-people icon-xl text-success mb-2\"></i><br><small>Users</small></div></div><div class=\"col-md-3 text-center\"><div class=\"p-3 border rounded\"><i class=\"bi bi-graph-up icon-xl text-info mb-2\"></i><br><small>Analytics</small></div></div><div class=\"col-md-3 text-center\"><div class=\"p-3 border rounded\"><i class=\"bi bi-gear icon-xl text-warning mb-2\"></i><br><small>Settings</small></div></div></div><h6 class=\"mt-4\">Icon Animations</h6><div class=\"row g-3\"><div class=\"col-md-3 text-center\"><i class=\"bi bi-arrow-clockwise icon-xl icon-spin text-primary\"></i><br><small>Spin</small></div><div class=\"col-md-3 text-center\"><i class=\"bi bi-heart icon-xl icon-pulse text-danger\"></i><br><small>Pulse</small></div><div class=\"col-md-3 text-center\"><i class=\"bi bi-star icon-xl icon-hover text-warning\"></i><br><small>Hover Effect</small></div><div class=\"col-md-3 text-center\"><i class=\"bi bi-check-circle icon-xl text-success\"></i><br><small>Static</small></div></div></div><div class=\"modal-footer\"><button type=\"button\" class=\"btn btn-secondary\" data-bs-dismiss=\"modal\"><i class=\"bi bi-x me-2\"></i>Close</button></div></div></div></div><!-- Scripts --><script>\n        document.addEventListener('DOMContentLoaded', () => {\n            const toggleButton = document.querySelector('[data-sidebar-toggle]');\n            const wrapper = document.getElementById('admin-wrapper');\n\n            if (toggleButton && wrapper) {\n            // Set initial state from localStorage\n            const isCollapsed = localStorage.getItem('sidebar-collapsed') === 'true';\n            if (isCollapsed) {\n                wrapper.classList.add('sidebar-collapsed');\n                toggleButton.classList.add('is-active');\n            }\n\n            // Attach click listener\n            toggleButton.addEventListener('click', () => {\n                const isCurrentlyCollapsed = wrapper.classList.contains('sidebar-collapsed');\n                \n                if (isCurrentlyCollapsed) {\n                wrapper.classList.remove('sidebar-collapsed');\n                toggleButton.classList.remove('is-active');\n                localStorage.setItem('sidebar-collapsed', 'false');\n                } else {\n                wrapper.classList.add('sidebar-collapsed');\n                toggleButton.classList.add('is-active');\n                localStorage.setItem('sidebar-collapsed', 'true');\n                }\n            });\n            }\n        });\n        </script><!-- New Item Modal --><div class=\"modal fade\" id=\"newItemModal\" tabindex=\"-1\" aria-labelledby=\"newItemModalLabel\" aria-hidden=\"true\"><div class=\"modal-dialog modal-dialog-centered\"><div class=\"modal-content\"><div class=\"modal-header border-0 pb-0\"><h5 class=\"modal-title\" id=\"newItemModalLabel\"><i class=\"bi bi-plus-circle text-primary me-2\"></i> Quick Add</h5><button type=\"button\" class=\"btn-close\" data-bs-dismiss=\"modal\" aria-label=\"Close\"></button></div><div class=\"modal-body\" x-data=\"quickAddForm()\"><p class=\"text-muted small mb-4\">Create a new item quickly from the dashboard.</p><!-- Item Type Selection --><div class=\"mb-4\"><label class=\"form-label fw-semibold\">What would you like to add?</label><div class=\"btn-group w-100\" role=\"group\"><button type=\"button\" class=\"btn btn-outline-primary btn-sm\" :class=\"{ 'active': itemType === 'task' }\" @click=\"itemType = 'task'\"><i class=\"bi bi-check2-square\"></i> Task</button> <button type=\"button\" class=\"btn btn-outline-success btn-sm\" :class=\"{ 'active': itemType === 'note' }\" @click=\"itemType = 'note'\"><i class=\"bi bi-sticky\"></i> Note</button> <button type=\"button\" class=\"btn btn-outline-info btn-sm\" :class=\"{ 'active': itemType === 'event' }\" @click=\"itemType = 'event'\"><i class=\"bi bi-calendar-event\"></i> Event</button> <button type=\"button\" class=\"btn btn-outline-warning btn-sm\" :class=\"{ 'active': itemType === 'reminder' }\" @click=\"itemType = 'reminder'\"><i class=\"bi bi-bell\"></i> Reminder</button></div></div><!-- Title --><div class=\"mb-3\"><label for=\"itemTitle\" class=\"form-label fw-semibold\">Title</label> <input type=\"text\" class=\"form-control\" id=\"itemTitle\" x-model=\"title\" placeholder=\"Enter a title...\" autofocus></div><!-- Description --><div class=\"mb-3\"><label for=\"itemDescription\" class=\"form-label fw-semibold\">Description</label> <textarea class=\"form-control\" id=\"itemDescription\" rows=\"3\" x-model=\"description\" placeholder=\"Add some details...\"></textarea></div><!-- Priority (shown for tasks) --><div class=\"mb-3\" x-show=\"itemType === 'task'\" x-transition><label class=\"form-label fw-semibold d-block\">Priority</label><div class=\"btn-group\" role=\"group\" aria-label=\"Priority selection\"><input type=\"radio\" class=\"btn-check\" name=\"priorityRadio\" id=\"priorityLow\" value=\"low\" x-model=\"priority\" autocomplete=\"off\"> <label class=\"btn btn-outline-success btn-sm\" for=\"priorityLow\"><i class=\"bi bi-flag\"></i> Low</label> <input type=\"radio\" class=\"btn-check\" name=\"priorityRadio\" id=\"priorityMedium\" value=\"medium\" x-model=\"priority\" autocomplete=\"off\"> <label class=\"btn btn-outline-warning btn-sm\" for=\"priorityMedium\"><i class=\"bi bi-flag-fill\"></i> Medium</label> <input type=\"radio\" class=\"btn-check\" name=\"priorityRadio\" id=\"priorityHigh\" value=\"high\" x-model=\"priority\" autocomplete=\"off\"> <label class=\"btn btn-outline-danger btn-sm\" for=\"priorityHigh\"><i class=\"bi bi-flag-fill\"></i> High</label></div></div><!-- Date (shown for events/reminders) --><div class=\"mb-3\" x-show=\"itemType === 'event' || itemType === 'reminder'\" x-transition><label for=\"itemDate\" class=\"form-label fw-semibold\">Date & Time</label> <input type=\"datetime-local\" class=\"form-control\" id=\"itemDate\" x-model=\"dateTime\"></div><!-- Assign to (shown for tasks) --><div class=\"mb-3\" x-show=\"itemType === 'task'\" x-transition><label for=\"assignTo\" class=\"form-label fw-semibold\">Assign to</label> <select class=\"form-select\" id=\"assignTo\" x-model=\"assignee\"><option value=\"\">Select team member...</option> <option value=\"john\">John Doe</option> <option value=\"jane\">Jane Smith</option> <option value=\"mike\">Mike Johnson</option> <option value=\"sarah\">Sarah Williams</option></select></div></div><div class=\"modal-footer border-0 pt-0\"><button type=\"button\" class=\"btn btn-light\" data-bs-dismiss=\"modal\">Cancel</button> <button type=\"button\" class=\"btn btn-primary\" @click=\"saveItem()\" data-bs-dismiss=\"modal\"><i class=\"bi bi-check-lg me-1\"></i> Create Item</button></div></div></div></div></body></html>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, "\" data-reopen-modal></button><script>\n        window.addEventListener(\"load\", () => document.querySelector(\"[data-reopen-modal]\").click());\n    </script>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -8,7 +8,9 @@ package views
 import "github.com/a-h/templ"
 import templruntime "github.com/a-h/templ/runtime"
 
-func RegisterPage(title string, csrfToken string) templ.Component {
+import "github.com/tikimcrzx723/alejandrinasweb/internal/dtos"
+
+func RegisterPage(title string, csrfToken string, form dtos.RegisterUserForm, errors map[string]string) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
@@ -48,7 +50,7 @@ func RegisterPage(title string, csrfToken string) templ.Component {
 			var templ_7745c5c3_Var3 templ.SafeURL
 			templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinURLErrs(templ.SafeURL("/register"))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/register.templ`, Line: 13, Col: 56}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/register.templ`, Line: 15, Col: 56}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
 			if templ_7745c5c3_Err != nil {
@@ -61,13 +63,105 @@ func RegisterPage(title string, csrfToken string) templ.Component {
 			var templ_7745c5c3_Var4 string
 			templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(csrfToken)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/register.templ`, Line: 14, Col: 82}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/register.templ`, Line: 16, Col: 82}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "\"><div class=\"single-form form-default form-border\"><label for=\"first_name\">Nombre(s)</label><div class=\"form-input\"><input name=\"first_name\" type=\"text\" placeholder=\"tu nombre\"> <i class=\"lni lni-user\"></i></div></div><div class=\"single-form form-default form-border\"><label for=\"last_name\">Apellidos</label><div class=\"form-input\"><input name=\"last_name\" type=\"text\" placeholder=\"tus apellidos\"> <i class=\"lni lni-user\"></i></div></div><div class=\"single-form form-default form-border\"><label for=\"phone\">Numero de Telefono</label><div class=\"form-input\"><input name=\"phone\" type=\"text\" placeholder=\"0000000000\"> <i class=\"lni lni-phone\"></i></div></div><div class=\"single-form form-default form-border\"><label for=\"email\">Correo Electrónico</label><div class=\"form-input\"><input id=\"email\" name=\"email\" type=\"email\" placeholder=\"user@email.com\"> <i class=\"mdi mdi-email\"></i></div></div><div class=\"single-form form-default form-border\"><label for=\"password\">Your Password</label><div class=\"form-input\"><input id=\"password-7\" name=\"password\" type=\"password\" placeholder=\"Password\"> <i class=\"mdi mdi-lock\"></i> <span toggle=\"#password-7\" class=\"mdi mdi-eye-outline toggle-password\"></span></div></div><div class=\"login-checkbox-forget d-sm-flex justify-content-between align-items-center\"><div class=\"single-checkbox checkbox-style-3\"><input type=\"checkbox\" id=\"login-7\"> <label for=\"login-7\"><span></span></label><p>Remember Me</p></div></div><div class=\"single-form\"><button class=\"main-btn primary-btn\">Sign in</button></div></form></div><div class=\"text-center\"><p class=\"login\">Don’t have an account? <a href=\"signup-page.html\">Sign up</a></p></div></div></div></div></div></section><!--====== Login Part Ends ======-->")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "\"><div class=\"single-form form-default form-border\"><label for=\"first_name\">Nombre(s)</label><div class=\"form-input\"><input id=\"first_name\" name=\"first_name\" type=\"text\" placeholder=\"tu nombre\" value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var5 string
+			templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(form.FirstName)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/register.templ`, Line: 20, Col: 120}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "\"> <i class=\"lni lni-user\"></i></div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = fieldError(errors, "first_name").Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</div><div class=\"single-form form-default form-border\"><label for=\"last_name\">Apellidos</label><div class=\"form-input\"><input id=\"last_name\" name=\"last_name\" type=\"text\" placeholder=\"tus apellidos\" value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var6 string
+			templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(form.LastName)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/register.templ`, Line: 28, Col: 121}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "\"> <i class=\"lni lni-user\"></i></div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = fieldError(errors, "last_name").Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "</div><div class=\"single-form form-default form-border\"><label for=\"phone\">Numero de Telefono</label><div class=\"form-input\"><input id=\"phone\" name=\"phone\" type=\"text\" placeholder=\"0000000000\" value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var7 string
+			templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(form.Phone)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/register.templ`, Line: 36, Col: 107}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "\"> <i class=\"lni lni-phone\"></i></div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = fieldError(errors, "phone").Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "</div><div class=\"single-form form-default form-border\"><label for=\"email\">Correo Electrónico</label><div class=\"form-input\"><input id=\"email\" name=\"email\" type=\"email\" placeholder=\"user@email.com\" value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var8 string
+			templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(form.Email)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/register.templ`, Line: 44, Col: 112}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "\"> <i class=\"mdi mdi-email\"></i></div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = fieldError(errors, "email").Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, "</div><div class=\"single-form form-default form-border\"><label for=\"password\">Your Password</label><div class=\"form-input\"><input id=\"password-7\" name=\"password\" type=\"password\" placeholder=\"Password\"> <i class=\"mdi mdi-lock\"></i> <span toggle=\"#password-7\" class=\"mdi mdi-eye-outline toggle-password\"></span></div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = fieldError(errors, "password").Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, "</div><div class=\"login-checkbox-forget d-sm-flex justify-content-between align-items-center\"><div class=\"single-checkbox checkbox-style-3\"><input type=\"checkbox\" id=\"login-7\"> <label for=\"login-7\"><span></span></label><p>Remember Me</p></div></div><div class=\"single-form\"><button class=\"main-btn primary-btn\">Sign in</button></div></form></div><div class=\"text-center\"><p class=\"login\">Don’t have an account? <a href=\"signup-page.html\">Sign up</a></p></div></div></div></div></div></section><!--====== Login Part Ends ======-->")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}